@@ -31,17 +31,19 @@ import (
 
 // Config admission-controller server config.
 type Config struct {
-	Master                    string
-	Kubeconfig                string
-	CertFile                  string
-	KeyFile                   string
-	CaCertFile                string
-	Port                      int
-	MutateWebhookConfigName   string
-	MutateWebhookName         string
-	ValidateWebhookConfigName string
-	ValidateWebhookName       string
-	PrintVersion              bool
+	Master                     string
+	Kubeconfig                 string
+	CertFile                   string
+	KeyFile                    string
+	CaCertFile                 string
+	Port                       int
+	MutateWebhookConfigName    string
+	MutateWebhookName          string
+	ValidateWebhookConfigName  string
+	ValidateWebhookName        string
+	ValidateQueueWebhookName   string
+	ValidateCronJobWebhookName string
+	PrintVersion               bool
 }
 
 // NewConfig create new config
@@ -68,6 +70,10 @@ func (c *Config) AddFlags() {
 		"Name of the mutatingwebhookconfiguration resource in Kubernetes.")
 	flag.StringVar(&c.ValidateWebhookName, "validate-webhook-name", "validatejob.volcano.sh",
 		"Name of the webhook entry in the webhook config.")
+	flag.StringVar(&c.ValidateQueueWebhookName, "validate-queue-webhook-name", "validatequeue.volcano.sh",
+		"Name of the queue webhook entry in the validating webhook config.")
+	flag.StringVar(&c.ValidateCronJobWebhookName, "validate-cronjob-webhook-name", "validatecronjob.volcano.sh",
+		"Name of the cronjob webhook entry in the validating webhook config.")
 	flag.BoolVar(&c.PrintVersion, "version", false, "Show version and quit")
 }
 
@@ -39,6 +39,14 @@ func serveMutateJobs(w http.ResponseWriter, r *http.Request) {
 	app.Serve(w, r, admissioncontroller.MutateJobs)
 }
 
+func serveQueues(w http.ResponseWriter, r *http.Request) {
+	app.Serve(w, r, admissioncontroller.AdmitQueues)
+}
+
+func serveCronJobs(w http.ResponseWriter, r *http.Request) {
+	app.Serve(w, r, admissioncontroller.AdmitCronJobs)
+}
+
 func main() {
 	config := appConf.NewConfig()
 	config.AddFlags()
@@ -50,6 +58,8 @@ func main() {
 
 	http.HandleFunc(admissioncontroller.AdmitJobPath, serveJobs)
 	http.HandleFunc(admissioncontroller.MutateJobPath, serveMutateJobs)
+	http.HandleFunc(admissioncontroller.AdmitQueuePath, serveQueues)
+	http.HandleFunc(admissioncontroller.AdmitCronJobPath, serveCronJobs)
 
 	if err := config.CheckPortOrDie(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
@@ -80,6 +90,14 @@ func main() {
 			config.ValidateWebhookConfigName, config.ValidateWebhookName, caCertPem); err != nil {
 			fmt.Fprintf(os.Stderr, "%v\n", err)
 		}
+		if err = appConf.PatchValidateWebhookConfig(clientset.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations(),
+			config.ValidateWebhookConfigName, config.ValidateQueueWebhookName, caCertPem); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		if err = appConf.PatchValidateWebhookConfig(clientset.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations(),
+			config.ValidateWebhookConfigName, config.ValidateCronJobWebhookName, caCertPem); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
 	}
 
 	server := &http.Server{
@@ -42,8 +42,10 @@ import (
 
 	"volcano.sh/volcano/cmd/controllers/app/options"
 	vkclient "volcano.sh/volcano/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/controllers/cronjob"
 	"volcano.sh/volcano/pkg/controllers/garbagecollector"
 	"volcano.sh/volcano/pkg/controllers/job"
+	"volcano.sh/volcano/pkg/controllers/jobflow"
 	"volcano.sh/volcano/pkg/controllers/queue"
 )
 
@@ -87,11 +89,15 @@ func Run(opt *options.ServerOption) error {
 
 	jobController := job.NewJobController(kubeClient, kbClient, vkClient, opt.WorkerThreads)
 	queueController := queue.NewQueueController(kubeClient, kbClient)
+	jobFlowController := jobflow.NewJobFlowController(vkClient)
+	cronJobController := cronjob.NewCronJobController(kubeClient, vkClient)
 	garbageCollector := garbagecollector.New(vkClient)
 
 	run := func(ctx context.Context) {
 		go jobController.Run(ctx.Done())
 		go queueController.Run(ctx.Done())
+		go jobFlowController.Run(ctx.Done())
+		go cronJobController.Run(ctx.Done())
 		go garbageCollector.Run(ctx.Done())
 		<-ctx.Done()
 	}
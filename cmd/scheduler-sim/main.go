@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/spf13/pflag"
+
+	"k8s.io/apiserver/pkg/util/flag"
+
+	"volcano.sh/volcano/pkg/scheduler"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/simulator"
+
+	// Import default actions/plugins.
+	_ "volcano.sh/volcano/pkg/scheduler/actions"
+	_ "volcano.sh/volcano/pkg/scheduler/plugins"
+)
+
+var (
+	schedulerConf = pflag.String("scheduler-conf", "", "The absolute path of scheduler configuration file; the default configuration is used if empty")
+	defaultQueue  = pflag.String("default-queue", "default", "The default queue name of the job")
+	cycles        = pflag.Int("cycles", 1, "The number of scheduling cycles to simulate")
+	inputs        = pflag.StringSlice("input", nil, "The YAML files or directories of Nodes, Pods, PodGroups, Queues and PriorityClasses to load")
+	pluginsDir    = pflag.String("plugins-dir", "", "The directory of custom plugins and actions built as Go plugins (.so files); requires the simulator built with cgo")
+	replay        = pflag.String("replay", "", "The snapshot recorded by vc-scheduler --snapshot-dir to replay for one scheduling cycle; --input and --cycles are ignored if set")
+)
+
+func main() {
+	flag.InitFlags()
+	defer glog.Flush()
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if len(*replay) != 0 {
+		*inputs = []string{*replay}
+		*cycles = 1
+	}
+
+	if len(*inputs) == 0 {
+		return fmt.Errorf("no input specified, use --input to load cluster objects or --replay to load a snapshot")
+	}
+
+	if err := framework.LoadCustomPlugins(*pluginsDir); err != nil {
+		return err
+	}
+
+	actions, tiers, err := scheduler.LoadSchedulerConf(*schedulerConf)
+	if err != nil {
+		return fmt.Errorf("failed to load scheduler configuration: %v", err)
+	}
+
+	objs, err := simulator.LoadObjects(*inputs)
+	if err != nil {
+		return err
+	}
+
+	cache := simulator.NewCache(*defaultQueue)
+	for _, obj := range objs {
+		if err := cache.Add(obj); err != nil {
+			glog.Warningf("Skip object: %v", err)
+		}
+	}
+
+	results := simulator.New(cache, actions, tiers).Run(*cycles)
+	simulator.PrintResults(os.Stdout, results)
+
+	return nil
+}
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -31,23 +33,41 @@ const (
 
 	defaultQPS   = 50.0
 	defaultBurst = 100
+
+	defaultSnapshotPeriod   = time.Minute
+	defaultSnapshotMaxFiles = 10
+
+	defaultParallelism             = 16
+	defaultPercentageOfNodesToFind = 100
+
+	defaultReservationTimeout = 30 * time.Minute
 )
 
 // ServerOption is the main context object for the controller manager.
 type ServerOption struct {
-	Master               string
-	Kubeconfig           string
-	SchedulerName        string
-	SchedulerConf        string
-	SchedulePeriod       time.Duration
-	EnableLeaderElection bool
-	LockObjectNamespace  string
-	DefaultQueue         string
-	PrintVersion         bool
-	ListenAddress        string
-	EnablePriorityClass  bool
-	KubeAPIBurst         int
-	KubeAPIQPS           float32
+	Master                  string
+	Kubeconfig              string
+	SchedulerName           string
+	SchedulerConf           string
+	SchedulePeriod          time.Duration
+	EnableLeaderElection    bool
+	LockObjectNamespace     string
+	LockObjectName          string
+	DefaultQueue            string
+	PrintVersion            bool
+	ListenAddress           string
+	EnablePriorityClass     bool
+	KubeAPIBurst            int
+	KubeAPIQPS              float32
+	SnapshotDir             string
+	SnapshotPeriod          time.Duration
+	SnapshotMaxFiles        int
+	PluginsDir              string
+	Parallelism             int
+	PercentageOfNodesToFind int
+	NodeSelector            string
+	Queues                  []string
+	ReservationTimeout      time.Duration
 }
 
 // ServerOpts server options
@@ -73,11 +93,26 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 			"executing the main loop. Enable this when running replicated kube-batch for high availability")
 	fs.BoolVar(&s.PrintVersion, "version", false, "Show version and quit")
 	fs.StringVar(&s.LockObjectNamespace, "lock-object-namespace", s.LockObjectNamespace, "Define the namespace of the lock object that is used for leader election")
+	fs.StringVar(&s.LockObjectName, "lock-object-name", s.LockObjectName, "Define the name of the lock object that is used for leader election, defaults to scheduler-name; "+
+		"it must be unique for each partition if several schedulers share the cluster")
 	fs.StringVar(&s.ListenAddress, "listen-address", defaultListenAddress, "The address to listen on for HTTP requests.")
 	fs.BoolVar(&s.EnablePriorityClass, "priority-class", true,
 		"Enable PriorityClass to provide the capacity of preemption at pod group level; to disable it, set it false")
 	fs.Float32Var(&s.KubeAPIQPS, "kube-api-qps", defaultQPS, "QPS to use while talking with kubernetes apiserver")
 	fs.IntVar(&s.KubeAPIBurst, "kube-api-burst", defaultBurst, "Burst to use while talking with kubernetes apiserver")
+	fs.StringVar(&s.SnapshotDir, "snapshot-dir", "", "The directory to record snapshots of scheduler cache into, which could be replayed by vc-scheduler-sim; disabled if empty")
+	fs.DurationVar(&s.SnapshotPeriod, "snapshot-period", defaultSnapshotPeriod, "The minimal period between recording two snapshots of scheduler cache")
+	fs.IntVar(&s.SnapshotMaxFiles, "snapshot-max-files", defaultSnapshotMaxFiles, "The maximum number of snapshots kept in snapshot-dir, the oldest ones are removed")
+	fs.IntVar(&s.Parallelism, "parallelism", defaultParallelism, "The number of workers evaluating predicates and scoring nodes for a task")
+	fs.IntVar(&s.PercentageOfNodesToFind, "percentage-of-nodes-to-find", defaultPercentageOfNodesToFind,
+		"The percentage of nodes to find feasible for a task in allocate action before scoring them; all nodes are predicated if 100")
+	fs.StringVar(&s.NodeSelector, "node-selector", "", "The label selector of nodes handled by the scheduler, all nodes if empty; "+
+		"used with --queues to partition the cluster between schedulers")
+	fs.StringSliceVar(&s.Queues, "queues", nil, "The queues whose jobs are scheduled by the scheduler, all queues if empty; "+
+		"used with --node-selector to partition the cluster between schedulers")
+	fs.DurationVar(&s.ReservationTimeout, "reservation-timeout", defaultReservationTimeout,
+		"The maximum time the nodes are locked by reserve action for a job, the job is not targeted again after that")
+	fs.StringVar(&s.PluginsDir, "plugins-dir", "", "The directory of custom plugins and actions built as Go plugins (.so files), which could be referenced in scheduler configuration; requires the scheduler built with cgo")
 }
 
 // CheckOptionOrDie check lock-object-namespace when LeaderElection is enabled
@@ -85,6 +120,21 @@ func (s *ServerOption) CheckOptionOrDie() error {
 	if s.EnableLeaderElection && s.LockObjectNamespace == "" {
 		return fmt.Errorf("lock-object-namespace must not be nil when LeaderElection is enabled")
 	}
+	if s.SnapshotDir != "" && s.SnapshotMaxFiles <= 0 {
+		return fmt.Errorf("snapshot-max-files must be positive when snapshot-dir is set")
+	}
+	if _, err := labels.Parse(s.NodeSelector); err != nil {
+		return fmt.Errorf("invalid node-selector %q: %v", s.NodeSelector, err)
+	}
+	if s.Parallelism <= 0 {
+		return fmt.Errorf("parallelism must be positive")
+	}
+	if s.PercentageOfNodesToFind <= 0 || s.PercentageOfNodesToFind > 100 {
+		return fmt.Errorf("percentage-of-nodes-to-find must be in range (0, 100]")
+	}
+	if s.ReservationTimeout <= 0 {
+		return fmt.Errorf("reservation-timeout must be positive")
+	}
 
 	return nil
 }
@@ -37,12 +37,17 @@ func TestAddFlags(t *testing.T) {
 
 	// This is a snapshot of expected options parsed by args.
 	expected := &ServerOption{
-		SchedulerName:  defaultSchedulerName,
-		SchedulePeriod: 5 * time.Minute,
-		DefaultQueue:   defaultQueue,
-		ListenAddress:  defaultListenAddress,
-		KubeAPIBurst:   defaultBurst,
-		KubeAPIQPS:     defaultQPS,
+		SchedulerName:           defaultSchedulerName,
+		SchedulePeriod:          5 * time.Minute,
+		DefaultQueue:            defaultQueue,
+		ListenAddress:           defaultListenAddress,
+		KubeAPIBurst:            defaultBurst,
+		KubeAPIQPS:              defaultQPS,
+		SnapshotPeriod:          defaultSnapshotPeriod,
+		SnapshotMaxFiles:        defaultSnapshotMaxFiles,
+		Parallelism:             defaultParallelism,
+		PercentageOfNodesToFind: defaultPercentageOfNodesToFind,
+		ReservationTimeout:      defaultReservationTimeout,
 	}
 
 	if !reflect.DeepEqual(expected, s) {
@@ -27,6 +27,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler"
+	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/version"
 
 	v1 "k8s.io/api/core/v1"
@@ -77,6 +78,10 @@ func Run(opt *options.ServerOption) error {
 		version.PrintVersionAndExit()
 	}
 
+	if err := framework.LoadCustomPlugins(opt.PluginsDir); err != nil {
+		return err
+	}
+
 	config, err := buildConfig(opt)
 	if err != nil {
 		return err
@@ -93,6 +98,7 @@ func Run(opt *options.ServerOption) error {
 
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
+		http.HandleFunc(scheduler.ExplainPath, scheduler.ExplainHandler)
 		glog.Fatalf("Prometheus Http Server failed %s", http.ListenAndServe(opt.ListenAddress, nil))
 	}()
 
@@ -123,9 +129,14 @@ func Run(opt *options.ServerOption) error {
 	// add a uniquifier so that two processes on the same host don't accidentally both become active
 	id := hostname + "_" + string(uuid.NewUUID())
 
+	lockObjectName := opt.LockObjectName
+	if len(lockObjectName) == 0 {
+		lockObjectName = opt.SchedulerName
+	}
+
 	rl, err := resourcelock.New(resourcelock.ConfigMapsResourceLock,
 		opt.LockObjectNamespace,
-		opt.SchedulerName,
+		lockObjectName,
 		leaderElectionClient.CoreV1(),
 		resourcelock.ResourceLockConfig{
 			Identity:      id,
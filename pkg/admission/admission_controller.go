@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/helpers"
 )
 
 const (
@@ -38,6 +39,10 @@ const (
 	AdmitJobPath = "/jobs"
 	//MutateJobPath is the pattern for the mutating jobs
 	MutateJobPath = "/mutating-jobs"
+	//AdmitQueuePath is the pattern for the queues admission
+	AdmitQueuePath = "/queues"
+	//AdmitCronJobPath is the pattern for the cronjobs admission
+	AdmitCronJobPath = "/cronjobs"
 )
 
 //The AdmitFunc returns response
@@ -56,6 +61,7 @@ var policyEventMap = map[v1alpha1.Event]bool{
 	v1alpha1.PodEvictedEvent:    true,
 	v1alpha1.JobUnknownEvent:    true,
 	v1alpha1.TaskCompletedEvent: true,
+	v1alpha1.JobTimeoutEvent:    true,
 	v1alpha1.OutOfSyncEvent:     false,
 	v1alpha1.CommandIssuedEvent: false,
 }
@@ -112,19 +118,32 @@ func DecodeJob(object runtime.RawExtension, resource metav1.GroupVersionResource
 func validatePolicies(policies []v1alpha1.LifecyclePolicy, fldPath *field.Path) error {
 	var err error
 	policyEvents := map[v1alpha1.Event]struct{}{}
+	// Events of job level policies could be duplicated if scoped to different tasks.
+	taskEvents := map[string]map[v1alpha1.Event]struct{}{}
 	exitCodes := map[int32]struct{}{}
 
 	for _, policy := range policies {
-		if (policy.Event != "" || len(policy.Events) != 0) && policy.ExitCode != nil {
+		termination := policy.ExitCode != nil || len(policy.ExitCodes) != 0 || len(policy.Reasons) != 0
+		if (policy.Event != "" || len(policy.Events) != 0) && termination {
 			err = multierror.Append(err, fmt.Errorf("must not specify event and exitCode simultaneously"))
 			break
 		}
 
-		if policy.Event == "" && len(policy.Events) == 0 && policy.ExitCode == nil {
+		if policy.Event == "" && len(policy.Events) == 0 && !termination {
 			err = multierror.Append(err, fmt.Errorf("either event and exitCode should be specified"))
 			break
 		}
 
+		if policy.Timeout != nil && policy.Timeout.Duration <= 0 {
+			err = multierror.Append(err, fmt.Errorf("timeout must be positive"))
+			break
+		}
+
+		if policy.MinSucceeded != nil && !hasEvent(policy, v1alpha1.TaskCompletedEvent) {
+			err = multierror.Append(err, fmt.Errorf("minSucceeded is only valid with %s event", v1alpha1.TaskCompletedEvent))
+			break
+		}
+
 		if len(policy.Event) != 0 || len(policy.Events) != 0 {
 			bFlag := false
 			policyEventsList := getEventlist(policy)
@@ -140,11 +159,15 @@ func validatePolicies(policies []v1alpha1.LifecyclePolicy, fldPath *field.Path)
 					bFlag = true
 					break
 				}
-				if _, found := policyEvents[event]; found {
+				if _, found := taskEvents[policy.TaskName]; !found {
+					taskEvents[policy.TaskName] = map[v1alpha1.Event]struct{}{}
+				}
+				if _, found := taskEvents[policy.TaskName][event]; found {
 					err = multierror.Append(err, fmt.Errorf("duplicate event %v  across different policy", event))
 					bFlag = true
 					break
 				} else {
+					taskEvents[policy.TaskName][event] = struct{}{}
 					policyEvents[event] = struct{}{}
 				}
 			}
@@ -153,15 +176,21 @@ func validatePolicies(policies []v1alpha1.LifecyclePolicy, fldPath *field.Path)
 			}
 
 		} else {
-			if *policy.ExitCode == 0 {
-				err = multierror.Append(err, fmt.Errorf("0 is not a valid error code"))
-				break
+			if policy.ExitCode != nil {
+				if *policy.ExitCode == 0 {
+					err = multierror.Append(err, fmt.Errorf("0 is not a valid error code"))
+					break
+				}
+				if _, found := exitCodes[*policy.ExitCode]; found {
+					err = multierror.Append(err, fmt.Errorf("duplicate exitCode %v", *policy.ExitCode))
+					break
+				} else {
+					exitCodes[*policy.ExitCode] = struct{}{}
+				}
 			}
-			if _, found := exitCodes[*policy.ExitCode]; found {
-				err = multierror.Append(err, fmt.Errorf("duplicate exitCode %v", *policy.ExitCode))
+			if exitCodeErr := validateExitCodes(policy.ExitCodes); exitCodeErr != nil {
+				err = multierror.Append(err, exitCodeErr)
 				break
-			} else {
-				exitCodes[*policy.ExitCode] = struct{}{}
 			}
 		}
 	}
@@ -173,6 +202,21 @@ func validatePolicies(policies []v1alpha1.LifecyclePolicy, fldPath *field.Path)
 	return err
 }
 
+// validateExitCodes checks the exit codes, ranges and "NonZero" of policy,
+// none of them could match 0.
+func validateExitCodes(exprs []string) error {
+	for _, expr := range exprs {
+		matched, err := helpers.MatchExitCode(expr, 0)
+		if err != nil {
+			return err
+		}
+		if matched {
+			return fmt.Errorf("0 is not a valid error code in %q", expr)
+		}
+	}
+	return nil
+}
+
 func getEventlist(policy v1alpha1.LifecyclePolicy) []v1alpha1.Event {
 	policyEventsList := policy.Events
 	if len(policy.Event) > 0 {
@@ -182,6 +226,15 @@ func getEventlist(policy v1alpha1.LifecyclePolicy) []v1alpha1.Event {
 	return uniquePolicyEventlist
 }
 
+func hasEvent(policy v1alpha1.LifecyclePolicy, event v1alpha1.Event) bool {
+	for _, e := range getEventlist(policy) {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
 func removeDuplicates(EventList []v1alpha1.Event) []v1alpha1.Event {
 	keys := make(map[v1alpha1.Event]bool)
 	list := []v1alpha1.Event{}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/cronjob"
+)
+
+// cronJobNameMaxLength is the max length of CronJob name, so that the name
+// of Jobs created by it, suffixed with the scheduled time, is still valid.
+const cronJobNameMaxLength = 52
+
+// AdmitCronJobs is to admit cronjobs and return response
+func AdmitCronJobs(ar v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+
+	glog.V(3).Infof("admitting cronjobs -- %s", ar.Request.Operation)
+
+	cronJob, err := DecodeCronJob(ar.Request.Object, ar.Request.Resource)
+	if err != nil {
+		return ToAdmissionResponse(err)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+
+	switch ar.Request.Operation {
+	case v1beta1.Create, v1beta1.Update:
+		if msg := validateCronJob(cronJob); msg != "" {
+			reviewResponse.Allowed = false
+			reviewResponse.Result = &metav1.Status{Message: strings.TrimSpace(msg)}
+		}
+	default:
+		err := fmt.Errorf("expect operation to be 'CREATE' or 'UPDATE'")
+		return ToAdmissionResponse(err)
+	}
+
+	return &reviewResponse
+}
+
+// DecodeCronJob decodes the cronjob using deserializer from the raw object
+func DecodeCronJob(object runtime.RawExtension, resource metav1.GroupVersionResource) (v1alpha1.CronJob, error) {
+	cronJobResource := metav1.GroupVersionResource{Group: v1alpha1.SchemeGroupVersion.Group, Version: v1alpha1.SchemeGroupVersion.Version, Resource: "cronjobs"}
+	cronJob := v1alpha1.CronJob{}
+
+	if resource != cronJobResource {
+		err := fmt.Errorf("expect resource to be %s", cronJobResource)
+		return cronJob, err
+	}
+
+	deserializer := Codecs.UniversalDeserializer()
+	if _, _, err := deserializer.Decode(object.Raw, nil, &cronJob); err != nil {
+		return cronJob, err
+	}
+	glog.V(3).Infof("the cronjob struct is %+v", cronJob)
+
+	return cronJob, nil
+}
+
+func validateCronJob(cronJob v1alpha1.CronJob) string {
+	var msg string
+
+	if len(cronJob.Name) > cronJobNameMaxLength {
+		msg = msg + fmt.Sprintf(" the name of cronjob should be no more than %d characters;", cronJobNameMaxLength)
+	}
+
+	if _, err := cronjob.ParseSchedule(cronJob.Spec.Schedule); err != nil {
+		msg = msg + fmt.Sprintf(" invalid schedule %q: %v;", cronJob.Spec.Schedule, err)
+	}
+
+	switch cronJob.Spec.ConcurrencyPolicy {
+	case "", v1alpha1.AllowConcurrent, v1alpha1.ForbidConcurrent, v1alpha1.ReplaceConcurrent:
+	default:
+		msg = msg + fmt.Sprintf(" invalid concurrencyPolicy %s, valid policies are %v;", cronJob.Spec.ConcurrencyPolicy,
+			[]v1alpha1.ConcurrencyPolicy{v1alpha1.AllowConcurrent, v1alpha1.ForbidConcurrent, v1alpha1.ReplaceConcurrent})
+	}
+
+	if cronJob.Spec.StartingDeadlineSeconds != nil && *cronJob.Spec.StartingDeadlineSeconds < 0 {
+		msg = msg + " 'startingDeadlineSeconds' cannot be less than zero;"
+	}
+
+	return msg
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+)
+
+func TestAdmitCronJobs(t *testing.T) {
+	cronJobResource := metav1.GroupVersionResource{
+		Group:    v1alpha1.SchemeGroupVersion.Group,
+		Version:  v1alpha1.SchemeGroupVersion.Version,
+		Resource: "cronjobs",
+	}
+	newCronJob := func(schedule string, policy v1alpha1.ConcurrencyPolicy) *v1alpha1.CronJob {
+		return &v1alpha1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cronjob",
+				Namespace: "test",
+			},
+			Spec: v1alpha1.CronJobSpec{
+				Schedule:          schedule,
+				ConcurrencyPolicy: policy,
+			},
+		}
+	}
+	longName := newCronJob("0 2 * * *", "")
+	longName.Name = strings.Repeat("a", 53)
+	negative := int64(-1)
+	negativeDeadline := newCronJob("0 2 * * *", "")
+	negativeDeadline.Spec.StartingDeadlineSeconds = &negative
+
+	testCases := []struct {
+		Name        string
+		cronJob     *v1alpha1.CronJob
+		operation   v1beta1.Operation
+		ExpectAllow bool
+	}{
+		{
+			Name:        "create cronjob",
+			cronJob:     newCronJob("*/15 2-4 * * MON-FRI", v1alpha1.ForbidConcurrent),
+			operation:   v1beta1.Create,
+			ExpectAllow: true,
+		},
+		{
+			Name:        "create cronjob with predefined schedule",
+			cronJob:     newCronJob("@daily", ""),
+			operation:   v1beta1.Create,
+			ExpectAllow: true,
+		},
+		{
+			Name:        "create cronjob with invalid schedule",
+			cronJob:     newCronJob("0 25 * * *", ""),
+			operation:   v1beta1.Create,
+			ExpectAllow: false,
+		},
+		{
+			Name:        "update cronjob with incomplete schedule",
+			cronJob:     newCronJob("0 2 * *", ""),
+			operation:   v1beta1.Update,
+			ExpectAllow: false,
+		},
+		{
+			Name:        "create cronjob with unknown concurrency policy",
+			cronJob:     newCronJob("0 2 * * *", "Queue"),
+			operation:   v1beta1.Create,
+			ExpectAllow: false,
+		},
+		{
+			Name:        "create cronjob with negative starting deadline",
+			cronJob:     negativeDeadline,
+			operation:   v1beta1.Create,
+			ExpectAllow: false,
+		},
+		{
+			Name:        "create cronjob with too long name",
+			cronJob:     longName,
+			operation:   v1beta1.Create,
+			ExpectAllow: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		raw, err := json.Marshal(testCase.cronJob)
+		if err != nil {
+			t.Errorf("%s: CronJob Marshal Failed: %v", testCase.Name, err)
+		}
+
+		ar := v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Name:      testCase.cronJob.Name,
+				Resource:  cronJobResource,
+				Operation: testCase.operation,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		}
+
+		response := AdmitCronJobs(ar)
+		if response.Allowed != testCase.ExpectAllow {
+			t.Errorf("%s: expect allowed %v, but got %v: %v", testCase.Name, testCase.ExpectAllow, response.Allowed, response.Result)
+		}
+	}
+}
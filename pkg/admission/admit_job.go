@@ -33,6 +33,7 @@ import (
 	k8scorevalid "k8s.io/kubernetes/pkg/apis/core/validation"
 
 	"volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/plugins"
 )
 
@@ -57,10 +58,11 @@ func AdmitJobs(ar v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
 		msg = validateJob(job, &reviewResponse)
 		break
 	case v1beta1.Update:
-		_, err := DecodeJob(ar.Request.OldObject, ar.Request.Resource)
+		oldJob, err := DecodeJob(ar.Request.OldObject, ar.Request.Resource)
 		if err != nil {
 			return ToAdmissionResponse(err)
 		}
+		msg = validateJobUpdate(oldJob, job, &reviewResponse)
 		break
 	default:
 		err := fmt.Errorf("expect operation to be 'CREATE' or 'UPDATE'")
@@ -94,6 +96,31 @@ func validateJob(job v1alpha1.Job, reviewResponse *v1beta1.AdmissionResponse) st
 		return fmt.Sprintf("'ttlSecondsAfterFinished' cannot be less than zero.")
 	}
 
+	if job.Spec.ActiveDeadlineSeconds != nil && *job.Spec.ActiveDeadlineSeconds <= 0 {
+		reviewResponse.Allowed = false
+		return fmt.Sprintf("'activeDeadlineSeconds' must be greater than zero.")
+	}
+
+	if job.Spec.PendingTimeout != nil && job.Spec.PendingTimeout.Duration <= 0 {
+		reviewResponse.Allowed = false
+		return fmt.Sprintf("'pendingTimeout' must be greater than zero.")
+	}
+
+	if backoff := job.Spec.RestartBackoff; backoff != nil {
+		if backoff.InitialDelay != nil && backoff.InitialDelay.Duration <= 0 {
+			reviewResponse.Allowed = false
+			return fmt.Sprintf("'restartBackoff.initialDelay' must be greater than zero.")
+		}
+		if backoff.Multiplier != nil && *backoff.Multiplier < 1 {
+			reviewResponse.Allowed = false
+			return fmt.Sprintf("'restartBackoff.multiplier' cannot be less than one.")
+		}
+		if backoff.MaxDelay != nil && backoff.MaxDelay.Duration <= 0 {
+			reviewResponse.Allowed = false
+			return fmt.Sprintf("'restartBackoff.maxDelay' must be greater than zero.")
+		}
+	}
+
 	if len(job.Spec.Tasks) == 0 {
 		reviewResponse.Allowed = false
 		return fmt.Sprintf("No task specified in job spec")
@@ -128,8 +155,12 @@ func validateJob(job v1alpha1.Job, reviewResponse *v1beta1.AdmissionResponse) st
 		msg += validateTaskTemplate(task, job, index)
 	}
 
+	msg += validateTaskDependsOn(job.Spec.Tasks)
+
 	if totalReplicas < job.Spec.MinAvailable {
 		msg = msg + " 'minAvailable' should not be greater than total replicas in tasks;"
+	} else if independentReplicas(job.Spec.Tasks) < job.Spec.MinAvailable {
+		msg = msg + " 'minAvailable' should not be greater than replicas of tasks without dependsOn;"
 	}
 
 	if err := validatePolicies(job.Spec.Policies, field.NewPath("spec.policies")); err != nil {
@@ -137,6 +168,8 @@ func validateJob(job v1alpha1.Job, reviewResponse *v1beta1.AdmissionResponse) st
 			getValidEvents(), getValidActions())
 	}
 
+	msg += validatePolicyTasks(job)
+
 	// invalid job plugins
 	if len(job.Spec.Plugins) != 0 {
 		for name := range job.Spec.Plugins {
@@ -151,8 +184,52 @@ func validateJob(job v1alpha1.Job, reviewResponse *v1beta1.AdmissionResponse) st
 	}
 
 	// Check whether Queue already present or not
-	if _, err := KubeBatchClientSet.SchedulingV1alpha1().Queues().Get(job.Spec.Queue, metav1.GetOptions{}); err != nil {
+	if queue, err := KubeBatchClientSet.SchedulingV1alpha1().Queues().Get(job.Spec.Queue, metav1.GetOptions{}); err != nil {
 		msg = msg + fmt.Sprintf("Job not created with error: %v", err)
+	} else if !queue.IsOpen() {
+		msg = msg + fmt.Sprintf("Job not created as queue `%s` is not open.", job.Spec.Queue)
+	}
+
+	if msg != "" {
+		reviewResponse.Allowed = false
+	}
+
+	return msg
+}
+
+// validateJobUpdate only allows the replicas of tasks to be changed on an
+// existing job, e.g. to scale an elastic job; minAvailable and the tasks
+// themselves must stay the same.
+func validateJobUpdate(oldJob, newJob v1alpha1.Job, reviewResponse *v1beta1.AdmissionResponse) string {
+	var msg string
+	var totalReplicas int32
+
+	if newJob.Spec.MinAvailable != oldJob.Spec.MinAvailable {
+		msg = msg + " 'minAvailable' is not allowed to be updated;"
+	}
+
+	if len(newJob.Spec.Tasks) != len(oldJob.Spec.Tasks) {
+		reviewResponse.Allowed = false
+		return msg + " adding or removing tasks is not allowed;"
+	}
+
+	for index, task := range newJob.Spec.Tasks {
+		if task.Name != oldJob.Spec.Tasks[index].Name {
+			msg = msg + fmt.Sprintf(" task %s is not allowed to be renamed to %s;",
+				oldJob.Spec.Tasks[index].Name, task.Name)
+		}
+
+		if task.Replicas <= 0 {
+			msg = msg + fmt.Sprintf(" 'replicas' is not set positive in task: %s;", task.Name)
+		}
+
+		totalReplicas = totalReplicas + task.Replicas
+	}
+
+	if totalReplicas < newJob.Spec.MinAvailable {
+		msg = msg + " 'minAvailable' should not be greater than total replicas in tasks;"
+	} else if independentReplicas(newJob.Spec.Tasks) < newJob.Spec.MinAvailable {
+		msg = msg + " 'minAvailable' should not be greater than replicas of tasks without dependsOn;"
 	}
 
 	if msg != "" {
@@ -162,6 +239,113 @@ func validateJob(job v1alpha1.Job, reviewResponse *v1beta1.AdmissionResponse) st
 	return msg
 }
 
+// validatePolicyTasks checks the tasks referred by policies, and that
+// minSucceeded of TaskCompleted policies does not exceed the task replicas.
+func validatePolicyTasks(job v1alpha1.Job) string {
+	var msg string
+
+	replicas := map[string]int32{}
+	for _, task := range job.Spec.Tasks {
+		replicas[task.Name] = task.Replicas
+	}
+
+	checkMinSucceeded := func(policy v1alpha1.LifecyclePolicy, taskName string) {
+		if policy.MinSucceeded == nil {
+			return
+		}
+		if *policy.MinSucceeded <= 0 {
+			msg = msg + fmt.Sprintf(" 'minSucceeded' is not set positive in policy of task: %s;", taskName)
+		} else if *policy.MinSucceeded > replicas[taskName] {
+			msg = msg + fmt.Sprintf(" 'minSucceeded' should not be greater than replicas of task: %s;", taskName)
+		}
+	}
+
+	for _, task := range job.Spec.Tasks {
+		for _, policy := range task.Policies {
+			if len(policy.TaskName) != 0 {
+				msg = msg + fmt.Sprintf(" 'taskName' is only allowed in job level policies, found in task: %s;", task.Name)
+			}
+			checkMinSucceeded(policy, task.Name)
+		}
+	}
+
+	for _, policy := range job.Spec.Policies {
+		if len(policy.TaskName) == 0 {
+			if policy.MinSucceeded != nil {
+				for _, task := range job.Spec.Tasks {
+					checkMinSucceeded(policy, task.Name)
+				}
+			}
+			continue
+		}
+		if _, found := replicas[policy.TaskName]; !found {
+			msg = msg + fmt.Sprintf(" policy refers to unknown task %s;", policy.TaskName)
+			continue
+		}
+		checkMinSucceeded(policy, policy.TaskName)
+	}
+
+	return msg
+}
+
+// independentReplicas returns the replicas of the tasks which depend on no
+// other tasks; the pods of the other tasks are not created until the tasks
+// they depend on are running, so only these pods count towards minAvailable
+// at first.
+func independentReplicas(tasks []v1alpha1.TaskSpec) int32 {
+	var replicas int32
+	for _, task := range tasks {
+		if task.DependsOn == nil || len(task.DependsOn.Name) == 0 {
+			replicas += task.Replicas
+		}
+	}
+	return replicas
+}
+
+// validateTaskDependsOn checks that depended tasks exist, are valid phases and
+// have no circular dependency.
+func validateTaskDependsOn(tasks []v1alpha1.TaskSpec) string {
+	var msg string
+
+	dependsOn := map[string][]string{}
+	for _, task := range tasks {
+		dependsOn[task.Name] = nil
+	}
+
+	for _, task := range tasks {
+		if task.DependsOn == nil {
+			continue
+		}
+
+		if phase := task.DependsOn.Phase; phase != "" && phase != v1.PodRunning && phase != v1.PodSucceeded {
+			msg = msg + fmt.Sprintf(" invalid dependsOn phase %s in task: %s, valid phases are %v;",
+				phase, task.Name, []v1.PodPhase{v1.PodRunning, v1.PodSucceeded})
+		}
+
+		for _, name := range task.DependsOn.Name {
+			if name == task.Name {
+				msg = msg + fmt.Sprintf(" task %s can not depend on itself;", task.Name)
+				continue
+			}
+			if _, found := dependsOn[name]; !found {
+				msg = msg + fmt.Sprintf(" task %s depends on unknown task %s;", task.Name, name)
+				continue
+			}
+			dependsOn[task.Name] = append(dependsOn[task.Name], name)
+		}
+	}
+
+	var names []string
+	for _, task := range tasks {
+		names = append(names, task.Name)
+	}
+	if name, found := helpers.FindDependencyCycle(names, dependsOn); found {
+		msg = msg + fmt.Sprintf(" circular dependency found in task: %s;", name)
+	}
+
+	return msg
+}
+
 func validateTaskTemplate(task v1alpha1.TaskSpec, job v1alpha1.Job, index int) string {
 	var v1PodTemplate v1.PodTemplate
 	v1PodTemplate.Template = *task.Template.DeepCopy()
@@ -17,8 +17,10 @@ limitations under the License.
 package admission
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	kubebatchclient "volcano.sh/volcano/pkg/client/clientset/versioned/fake"
 
@@ -35,6 +37,9 @@ func TestValidateExecution(t *testing.T) {
 	namespace := "test"
 	var invTTL int32 = -1
 	var policyExitCode int32 = -1
+	var minSucceeded int32 = 2
+	var invDeadline int64
+	var invMultiplier int32
 
 	testCases := []struct {
 		Name           string
@@ -289,6 +294,117 @@ func TestValidateExecution(t *testing.T) {
 			ret:            "'ttlSecondsAfterFinished' cannot be less than zero",
 			ExpectErr:      true,
 		},
+		// active deadline illegal
+		{
+			Name: "job-active-deadline-illegal",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-active-deadline-illegal",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+					ActiveDeadlineSeconds: &invDeadline,
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: false},
+			ret:            "'activeDeadlineSeconds' must be greater than zero.",
+			ExpectErr:      true,
+		},
+		// pending timeout illegal
+		{
+			Name: "job-pending-timeout-illegal",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-pending-timeout-illegal",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+					PendingTimeout: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: false},
+			ret:            "'pendingTimeout' must be greater than zero.",
+			ExpectErr:      true,
+		},
+		// restart backoff illegal
+		{
+			Name: "job-restart-backoff-illegal",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-restart-backoff-illegal",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+					RestartBackoff: &v1alpha1.RestartBackoff{Multiplier: &invMultiplier},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: false},
+			ret:            "'restartBackoff.multiplier' cannot be less than one.",
+			ExpectErr:      true,
+		},
 		// min-MinAvailable less than zero
 		{
 			Name: "minAvailable-lessThanZero",
@@ -939,6 +1055,425 @@ func TestValidateExecution(t *testing.T) {
 			ret:            "Job not created with error: ",
 			ExpectErr:      true,
 		},
+		// job with closed queue
+		{
+			Name: "job-with-closedQueue",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-with-closedQueue",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "closed",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: true},
+			ret:            "Job not created as queue `closed` is not open.",
+			ExpectErr:      true,
+		},
+		// tasks depend on each other
+		{
+			Name: "job-with-circular-dependsOn",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-with-circular-dependsOn",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							DependsOn: &v1alpha1.DependsOn{
+								Name: []string{"task-2"},
+							},
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "task-2",
+							Replicas: 1,
+							DependsOn: &v1alpha1.DependsOn{
+								Name: []string{"task-1"},
+							},
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: true},
+			ret:            "circular dependency found in task: task-1;",
+			ExpectErr:      true,
+		},
+		// task depends on unknown task
+		{
+			Name: "job-with-unknown-dependsOn",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-with-unknown-dependsOn",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							DependsOn: &v1alpha1.DependsOn{
+								Name: []string{"task-2"},
+							},
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: true},
+			ret:            "task task-1 depends on unknown task task-2;",
+			ExpectErr:      true,
+		},
+		// minAvailable is satisfied by tasks without dependsOn
+		{
+			Name: "job-with-dependsOn",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-with-dependsOn",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "master",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "worker",
+							Replicas: 2,
+							DependsOn: &v1alpha1.DependsOn{
+								Name: []string{"master"},
+							},
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: true},
+			ret:            "",
+			ExpectErr:      false,
+		},
+		// minAvailable counts pods of tasks which are not created until others are running
+		{
+			Name: "job-with-dependsOn-minAvailable-exceeds",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-with-dependsOn-minAvailable-exceeds",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 3,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "master",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "worker",
+							Replicas: 2,
+							DependsOn: &v1alpha1.DependsOn{
+								Name: []string{"master"},
+							},
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: true},
+			ret:            "'minAvailable' should not be greater than replicas of tasks without dependsOn;",
+			ExpectErr:      true,
+		},
+		// TaskCompleted policies scoped to tasks
+		{
+			Name: "task-completed-policies",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "task-completed-policies",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "master",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "worker",
+							Replicas: 3,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Event:    v1alpha1.TaskCompletedEvent,
+							Action:   v1alpha1.CompleteJobAction,
+							TaskName: "master",
+						},
+						{
+							Event:        v1alpha1.TaskCompletedEvent,
+							Action:       v1alpha1.CompleteJobAction,
+							TaskName:     "worker",
+							MinSucceeded: &minSucceeded,
+						},
+					},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: true},
+			ret:            "",
+			ExpectErr:      false,
+		},
+		// minSucceeded greater than replicas
+		{
+			Name: "min-succeeded-illegal",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "min-succeeded-illegal",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "master",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:     "worker",
+							Replicas: 3,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Event:        v1alpha1.TaskCompletedEvent,
+							Action:       v1alpha1.CompleteJobAction,
+							TaskName:     "master",
+							MinSucceeded: &minSucceeded,
+						},
+					},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: true},
+			ret:            "'minSucceeded' should not be greater than replicas of task: master;",
+			ExpectErr:      true,
+		},
+		// exit code range containing 0
+		{
+			Name: "exit-codes-illegal",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "exit-codes-illegal",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Action:  v1alpha1.RestartJobAction,
+							Reasons: []string{"OOMKilled"},
+						},
+						{
+							Action:    v1alpha1.AbortJobAction,
+							ExitCodes: []string{"0-5"},
+						},
+					},
+				},
+			},
+			reviewResponse: v1beta1.AdmissionResponse{Allowed: true},
+			ret:            "0 is not a valid error code",
+			ExpectErr:      true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -960,6 +1495,20 @@ func TestValidateExecution(t *testing.T) {
 			t.Error("Queue Creation Failed")
 		}
 
+		//create closed queue
+		closedQueue := kbv1aplha1.Queue{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "closed",
+			},
+			Spec: kbv1aplha1.QueueSpec{
+				Weight: 1,
+				State:  kbv1aplha1.QueueStateClosed,
+			},
+		}
+		if _, err := KubeBatchClientSet.SchedulingV1alpha1().Queues().Create(&closedQueue); err != nil {
+			t.Error("Queue Creation Failed")
+		}
+
 		ret := validateJob(testCase.Job, &testCase.reviewResponse)
 		//fmt.Printf("test-case name:%s, ret:%v  testCase.reviewResponse:%v \n", testCase.Name, ret,testCase.reviewResponse)
 		if testCase.ExpectErr == true && ret == "" {
@@ -982,3 +1531,101 @@ func TestValidateExecution(t *testing.T) {
 	}
 
 }
+
+func TestValidateJobUpdate(t *testing.T) {
+	newJob := func(minAvailable int32, replicas ...int32) v1alpha1.Job {
+		job := v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "elastic-job",
+				Namespace: "test",
+			},
+			Spec: v1alpha1.JobSpec{
+				MinAvailable: minAvailable,
+				Queue:        "default",
+			},
+		}
+		for i, r := range replicas {
+			job.Spec.Tasks = append(job.Spec.Tasks, v1alpha1.TaskSpec{
+				Name:     fmt.Sprintf("task-%d", i),
+				Replicas: r,
+			})
+		}
+		return job
+	}
+	dependOnFirst := func(job v1alpha1.Job) v1alpha1.Job {
+		for i := 1; i < len(job.Spec.Tasks); i++ {
+			job.Spec.Tasks[i].DependsOn = &v1alpha1.DependsOn{Name: []string{job.Spec.Tasks[0].Name}}
+		}
+		return job
+	}
+
+	testCases := []struct {
+		Name      string
+		Old       v1alpha1.Job
+		New       v1alpha1.Job
+		ExpectErr bool
+		ret       string
+	}{
+		{
+			Name:      "scale up replicas",
+			Old:       newJob(2, 1, 2),
+			New:       newJob(2, 1, 4),
+			ExpectErr: false,
+		},
+		{
+			Name:      "scale down replicas",
+			Old:       newJob(2, 1, 4),
+			New:       newJob(2, 1, 1),
+			ExpectErr: false,
+		},
+		{
+			Name:      "scale down below minAvailable",
+			Old:       newJob(3, 1, 2),
+			New:       newJob(3, 1, 1),
+			ExpectErr: true,
+			ret:       "'minAvailable' should not be greater than total replicas in tasks;",
+		},
+		{
+			Name:      "scale down to zero",
+			Old:       newJob(1, 1, 2),
+			New:       newJob(1, 1, 0),
+			ExpectErr: true,
+			ret:       "'replicas' is not set positive in task: task-1;",
+		},
+		{
+			Name:      "update minAvailable",
+			Old:       newJob(1, 1, 2),
+			New:       newJob(2, 1, 2),
+			ExpectErr: true,
+			ret:       "'minAvailable' is not allowed to be updated;",
+		},
+		{
+			Name:      "add task",
+			Old:       newJob(1, 1, 2),
+			New:       newJob(1, 1, 2, 1),
+			ExpectErr: true,
+			ret:       "adding or removing tasks is not allowed;",
+		},
+		{
+			Name:      "scale down tasks without dependsOn below minAvailable",
+			Old:       dependOnFirst(newJob(2, 2, 2)),
+			New:       dependOnFirst(newJob(2, 1, 2)),
+			ExpectErr: true,
+			ret:       "'minAvailable' should not be greater than replicas of tasks without dependsOn;",
+		},
+	}
+
+	for _, testCase := range testCases {
+		reviewResponse := v1beta1.AdmissionResponse{Allowed: true}
+		ret := validateJobUpdate(testCase.Old, testCase.New, &reviewResponse)
+		if testCase.ExpectErr != (ret != "") {
+			t.Errorf("%s: expected error %t, but got %q", testCase.Name, testCase.ExpectErr, ret)
+		}
+		if testCase.ExpectErr == reviewResponse.Allowed {
+			t.Errorf("%s: expected Allowed as %t, but got %t", testCase.Name, !testCase.ExpectErr, reviewResponse.Allowed)
+		}
+		if !strings.Contains(ret, testCase.ret) {
+			t.Errorf("%s: expected error msg %q, but got %q", testCase.Name, testCase.ret, ret)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+
+	"k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kbv1alpha1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+)
+
+// AdmitQueues is to admit queues and return response
+func AdmitQueues(ar v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+
+	glog.V(3).Infof("admitting queues -- %s", ar.Request.Operation)
+
+	queueResource := metav1.GroupVersionResource{Group: kbv1alpha1.SchemeGroupVersion.Group, Version: kbv1alpha1.SchemeGroupVersion.Version, Resource: "queues"}
+	if ar.Request.Resource != queueResource {
+		err := fmt.Errorf("expect resource to be %s", queueResource)
+		return ToAdmissionResponse(err)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+
+	switch ar.Request.Operation {
+	case v1beta1.Create, v1beta1.Update:
+		queue, err := DecodeQueue(ar.Request.Object, ar.Request.Resource)
+		if err != nil {
+			return ToAdmissionResponse(err)
+		}
+		if msg := validateQueue(queue); msg != "" {
+			reviewResponse.Allowed = false
+			reviewResponse.Result = &metav1.Status{Message: msg}
+		}
+	case v1beta1.Delete:
+		if msg := validateQueueDeleting(ar.Request.Name); msg != "" {
+			reviewResponse.Allowed = false
+			reviewResponse.Result = &metav1.Status{Message: msg}
+		}
+	default:
+		err := fmt.Errorf("expect operation to be 'CREATE', 'UPDATE' or 'DELETE'")
+		return ToAdmissionResponse(err)
+	}
+
+	return &reviewResponse
+}
+
+// DecodeQueue decodes the queue using deserializer from the raw object
+func DecodeQueue(object runtime.RawExtension, resource metav1.GroupVersionResource) (kbv1alpha1.Queue, error) {
+	queueResource := metav1.GroupVersionResource{Group: kbv1alpha1.SchemeGroupVersion.Group, Version: kbv1alpha1.SchemeGroupVersion.Version, Resource: "queues"}
+	queue := kbv1alpha1.Queue{}
+
+	if resource != queueResource {
+		err := fmt.Errorf("expect resource to be %s", queueResource)
+		return queue, err
+	}
+
+	deserializer := Codecs.UniversalDeserializer()
+	if _, _, err := deserializer.Decode(object.Raw, nil, &queue); err != nil {
+		return queue, err
+	}
+	glog.V(3).Infof("the queue struct is %+v", queue)
+
+	return queue, nil
+}
+
+func validateQueue(queue kbv1alpha1.Queue) string {
+	switch queue.Spec.State {
+	case "", kbv1alpha1.QueueStateOpen, kbv1alpha1.QueueStateClosed:
+	default:
+		return fmt.Sprintf("invalid state `%s` of queue `%s`, should be `%s` or `%s`",
+			queue.Spec.State, queue.Name, kbv1alpha1.QueueStateOpen, kbv1alpha1.QueueStateClosed)
+	}
+
+	if len(queue.Spec.Parent) == 0 {
+		return ""
+	}
+
+	// Walk up the parents of the queue, which must exist and must not lead
+	// back to the queue itself.
+	visited := map[string]bool{queue.Name: true}
+	for parent := queue.Spec.Parent; len(parent) != 0; {
+		if visited[parent] {
+			return fmt.Sprintf("parent `%s` of queue `%s` makes a cycle in the queue hierarchy",
+				queue.Spec.Parent, queue.Name)
+		}
+		visited[parent] = true
+
+		parentQueue, err := KubeBatchClientSet.SchedulingV1alpha1().Queues().Get(parent, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Sprintf("parent queue `%s` of queue `%s` does not exist", parent, queue.Name)
+			}
+			return fmt.Sprintf("failed to get parent queue `%s` of queue `%s`: %v", parent, queue.Name, err)
+		}
+		parent = parentQueue.Spec.Parent
+	}
+
+	return ""
+}
+
+func validateQueueDeleting(queueName string) string {
+	if queueName == DefaultQueue {
+		return fmt.Sprintf("`%s` queue can not be deleted", DefaultQueue)
+	}
+
+	queue, err := KubeBatchClientSet.SchedulingV1alpha1().Queues().Get(queueName, metav1.GetOptions{})
+	if err != nil {
+		// Let apiserver report the error, e.g. not found.
+		glog.V(3).Infof("Failed to get queue %s: %v", queueName, err)
+		return ""
+	}
+
+	if podGroups := queue.Status.Pending + queue.Status.Running + queue.Status.Unknown; podGroups != 0 {
+		return fmt.Sprintf("queue `%s` can not be deleted as it still has %d PodGroups (pending %d, running %d, unknown %d)",
+			queueName, podGroups, queue.Status.Pending, queue.Status.Running, queue.Status.Unknown)
+	}
+
+	// Children would become root queues silently, which changes how the
+	// resources are divided between queues.
+	queues, err := KubeBatchClientSet.SchedulingV1alpha1().Queues().List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Sprintf("failed to list queues to check children of queue `%s`: %v", queueName, err)
+	}
+	var children []string
+	for _, q := range queues.Items {
+		if q.Spec.Parent == queueName {
+			children = append(children, q.Name)
+		}
+	}
+	if len(children) != 0 {
+		return fmt.Sprintf("queue `%s` can not be deleted as it is the parent of queues %v", queueName, children)
+	}
+
+	return ""
+}
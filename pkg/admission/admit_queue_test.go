@@ -0,0 +1,264 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+
+	kbv1aplha1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	kubebatchclient "volcano.sh/volcano/pkg/client/clientset/versioned/fake"
+)
+
+func TestAdmitQueues(t *testing.T) {
+	queueResource := metav1.GroupVersionResource{
+		Group:    kbv1aplha1.SchemeGroupVersion.Group,
+		Version:  kbv1aplha1.SchemeGroupVersion.Version,
+		Resource: "queues",
+	}
+
+	testCases := []struct {
+		Name        string
+		queue       *kbv1aplha1.Queue
+		existing    []*kbv1aplha1.Queue
+		operation   v1beta1.Operation
+		ExpectAllow bool
+	}{
+		{
+			Name: "delete default queue",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: DefaultQueue,
+				},
+			},
+			operation:   v1beta1.Delete,
+			ExpectAllow: false,
+		},
+		{
+			Name: "delete queue with podgroups",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "busy",
+				},
+				Status: kbv1aplha1.QueueStatus{
+					Running: 1,
+				},
+			},
+			operation:   v1beta1.Delete,
+			ExpectAllow: false,
+		},
+		{
+			Name: "delete empty queue",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "empty",
+				},
+			},
+			operation:   v1beta1.Delete,
+			ExpectAllow: true,
+		},
+		{
+			Name: "delete queue with children",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "root",
+				},
+			},
+			existing: []*kbv1aplha1.Queue{
+				{ObjectMeta: metav1.ObjectMeta{Name: "child"}, Spec: kbv1aplha1.QueueSpec{Parent: "root"}},
+			},
+			operation:   v1beta1.Delete,
+			ExpectAllow: false,
+		},
+		{
+			Name: "create queue",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new",
+				},
+			},
+			operation:   v1beta1.Create,
+			ExpectAllow: true,
+		},
+		{
+			Name: "create closed queue",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new",
+				},
+				Spec: kbv1aplha1.QueueSpec{
+					State: kbv1aplha1.QueueStateClosed,
+				},
+			},
+			operation:   v1beta1.Create,
+			ExpectAllow: true,
+		},
+		{
+			Name: "update queue to closing state",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new",
+				},
+				Spec: kbv1aplha1.QueueSpec{
+					State: kbv1aplha1.QueueStateClosing,
+				},
+			},
+			operation:   v1beta1.Update,
+			ExpectAllow: false,
+		},
+		{
+			Name: "create queue with unknown state",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new",
+				},
+				Spec: kbv1aplha1.QueueSpec{
+					State: "Paused",
+				},
+			},
+			operation:   v1beta1.Create,
+			ExpectAllow: false,
+		},
+		{
+			Name: "create queue with parent",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "child",
+				},
+				Spec: kbv1aplha1.QueueSpec{
+					Parent: "root",
+				},
+			},
+			existing: []*kbv1aplha1.Queue{
+				{ObjectMeta: metav1.ObjectMeta{Name: "root"}},
+			},
+			operation:   v1beta1.Create,
+			ExpectAllow: true,
+		},
+		{
+			Name: "create queue with missing parent",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "child",
+				},
+				Spec: kbv1aplha1.QueueSpec{
+					Parent: "root",
+				},
+			},
+			operation:   v1beta1.Create,
+			ExpectAllow: false,
+		},
+		{
+			Name: "create queue with missing ancestor",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "child",
+				},
+				Spec: kbv1aplha1.QueueSpec{
+					Parent: "middle",
+				},
+			},
+			existing: []*kbv1aplha1.Queue{
+				{ObjectMeta: metav1.ObjectMeta{Name: "middle"}, Spec: kbv1aplha1.QueueSpec{Parent: "root"}},
+			},
+			operation:   v1beta1.Create,
+			ExpectAllow: false,
+		},
+		{
+			Name: "update queue to be its own parent",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "root",
+				},
+				Spec: kbv1aplha1.QueueSpec{
+					Parent: "root",
+				},
+			},
+			existing: []*kbv1aplha1.Queue{
+				{ObjectMeta: metav1.ObjectMeta{Name: "root"}},
+			},
+			operation:   v1beta1.Update,
+			ExpectAllow: false,
+		},
+		{
+			Name: "update queue making cycle",
+			queue: &kbv1aplha1.Queue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "root",
+				},
+				Spec: kbv1aplha1.QueueSpec{
+					Parent: "leaf",
+				},
+			},
+			existing: []*kbv1aplha1.Queue{
+				{ObjectMeta: metav1.ObjectMeta{Name: "root"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "middle"}, Spec: kbv1aplha1.QueueSpec{Parent: "root"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "leaf"}, Spec: kbv1aplha1.QueueSpec{Parent: "middle"}},
+			},
+			operation:   v1beta1.Update,
+			ExpectAllow: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		client := kubebatchclient.NewSimpleClientset()
+		// The tracker of fake clientset could not list queues, as their
+		// list kind is not registered in the group of the fake client.
+		client.PrependReactor("list", "queues", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			list := &kbv1aplha1.QueueList{}
+			for _, queue := range testCase.existing {
+				list.Items = append(list.Items, *queue)
+			}
+			return true, list, nil
+		})
+		KubeBatchClientSet = client
+		for _, queue := range testCase.existing {
+			if _, err := KubeBatchClientSet.SchedulingV1alpha1().Queues().Create(queue); err != nil {
+				t.Errorf("%s: Queue Creation Failed: %v", testCase.Name, err)
+			}
+		}
+		if testCase.operation == v1beta1.Delete {
+			if _, err := KubeBatchClientSet.SchedulingV1alpha1().Queues().Create(testCase.queue); err != nil {
+				t.Errorf("%s: Queue Creation Failed: %v", testCase.Name, err)
+			}
+		}
+
+		raw, err := json.Marshal(testCase.queue)
+		if err != nil {
+			t.Errorf("%s: Queue Marshal Failed: %v", testCase.Name, err)
+		}
+
+		ar := v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Name:      testCase.queue.Name,
+				Resource:  queueResource,
+				Operation: testCase.operation,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		}
+
+		response := AdmitQueues(ar)
+		if response.Allowed != testCase.ExpectAllow {
+			t.Errorf("%s: expect allowed %v, but got %v: %v", testCase.Name, testCase.ExpectAllow, response.Allowed, response.Result)
+		}
+	}
+}
@@ -85,6 +85,42 @@ type JobSpec struct {
 	// If specified, indicates the job's priority.
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty" protobuf:"bytes,10,opt,name=priorityClassName"`
+
+	// Specifies the duration in seconds relative to the creation of the Job
+	// that the Job may be active before JobTimeout event is triggered; the
+	// Job is terminated by default.
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty" protobuf:"varint,11,opt,name=activeDeadlineSeconds"`
+
+	// Specifies the duration that the Job may stay in Pending phase, e.g.
+	// waiting for resources, before JobTimeout event is triggered; the Job
+	// is terminated by default. It is counted again after the Job restarts.
+	// +optional
+	PendingTimeout *metav1.Duration `json:"pendingTimeout,omitempty" protobuf:"bytes,12,opt,name=pendingTimeout"`
+
+	// Specifies the backoff before the pods of a restarted Job are recreated.
+	// If unset, the pods are recreated immediately.
+	// +optional
+	RestartBackoff *RestartBackoff `json:"restartBackoff,omitempty" protobuf:"bytes,13,opt,name=restartBackoff"`
+}
+
+// RestartBackoff specifies the exponential backoff of Job restarts: the n-th
+// restart waits InitialDelay * Multiplier^(n-1), up to MaxDelay.
+type RestartBackoff struct {
+	// The delay of the first restart.
+	// Defaults to 10s.
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty" protobuf:"bytes,1,opt,name=initialDelay"`
+
+	// The factor by which the delay grows for each further restart.
+	// Defaults to 2.
+	// +optional
+	Multiplier *int32 `json:"multiplier,omitempty" protobuf:"varint,2,opt,name=multiplier"`
+
+	// The maximum delay of restarts.
+	// Defaults to 5m.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty" protobuf:"bytes,3,opt,name=maxDelay"`
 }
 
 // VolumeSpec defines the specification of Volume, e.g. PVC
@@ -139,8 +175,14 @@ const (
 	CommandIssuedEvent Event = "CommandIssued"
 	// TaskCompletedEvent is triggered if the 'Replicas' amount of pods in one task are succeed
 	TaskCompletedEvent Event = "TaskCompleted"
+	// JobTimeoutEvent is triggered if the Job exceeds its activeDeadlineSeconds
+	// or pendingTimeout
+	JobTimeoutEvent Event = "JobTimeout"
 )
 
+// NonZeroExitCode matches any non-zero exit code in LifecyclePolicy.ExitCodes.
+const NonZeroExitCode = "NonZero"
+
 // Action is the action that Job controller will take according to the event.
 type Action string
 
@@ -187,14 +229,44 @@ type LifecyclePolicy struct {
 
 	// The exit code of the pod container, controller will take action
 	// according to this code.
-	// Note: only one of `Event` or `ExitCode` can be specified.
+	// Note: `Event` can not be specified with `ExitCode`, `ExitCodes` or `Reasons`.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty" protobuf:"varint,7,opt,name=exitCode"`
+
+	// ExitCodes are the exit codes of the pod container which trigger the
+	// action; each one is an exit code "3", a range "10-20", or "NonZero"
+	// for any non-zero exit code.
+	// +optional
+	ExitCodes []string `json:"exitCodes,omitempty" protobuf:"bytes,8,rep,name=exitCodes"`
+
+	// Reasons are the termination reasons of the pod or its container which
+	// trigger the action, e.g. "OOMKilled", "Evicted", "DeadlineExceeded".
+	// A policy with both exit codes and reasons applies if either matches.
+	// +optional
+	Reasons []string `json:"reasons,omitempty" protobuf:"bytes,9,rep,name=reasons"`
+
+	// ContainerName selects the container whose exit code and termination
+	// reason are checked; default to all the containers of the pod.
 	// +optional
-	ExitCode *int32
+	ContainerName string `json:"containerName,omitempty" protobuf:"bytes,10,opt,name=containerName"`
 
 	// Timeout is the grace period for controller to take actions.
 	// Default to nil (take action immediately).
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,4,opt,name=timeout"`
+
+	// TaskName scopes a job level policy to the events of the named task,
+	// e.g. TaskCompleted of the master task; if empty, the policy applies to
+	// the events of all tasks.
+	// +optional
+	TaskName string `json:"taskName,omitempty" protobuf:"bytes,5,opt,name=taskName"`
+
+	// MinSucceeded is the number of succeeded pods for a task to be
+	// completed in TaskCompleted event, e.g. the job completes once
+	// MinSucceeded workers succeed.
+	// Default to the replicas of the task.
+	// +optional
+	MinSucceeded *int32 `json:"minSucceeded,omitempty" protobuf:"bytes,6,opt,name=minSucceeded"`
 }
 
 // TaskSpec specifies the task specification of Job
@@ -212,6 +284,23 @@ type TaskSpec struct {
 	// Specifies the lifecycle of task
 	// +optional
 	Policies []LifecyclePolicy `json:"policies,omitempty" protobuf:"bytes,4,opt,name=policies"`
+
+	// Specifies the tasks that this task depends on; the pods of this task
+	// are created only after the depended tasks reach the specified phase.
+	// +optional
+	DependsOn *DependsOn `json:"dependsOn,omitempty" protobuf:"bytes,5,opt,name=dependsOn"`
+}
+
+// DependsOn represents the tasks that a task depends on.
+type DependsOn struct {
+	// Name specifies the names of the depended tasks.
+	Name []string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+
+	// Phase specifies the phase that all pods of the depended tasks should reach,
+	// one of "Running" or "Succeeded"; a succeeded pod is regarded as running.
+	// Defaults to Running.
+	// +optional
+	Phase v1.PodPhase `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase"`
 }
 
 // JobPhase defines the phase of the job
@@ -303,6 +392,56 @@ type JobStatus struct {
 
 	// The resources that controlled by this job, e.g. Service, ConfigMap
 	ControlledResources map[string]string `json:"controlledResources,omitempty" protobuf:"bytes,11,opt,name=controlledResources"`
+
+	// The conditions of the job, e.g. the actions pending for the timeout
+	// of policies.
+	// +optional
+	Conditions []JobCondition `json:"conditions,omitempty" protobuf:"bytes,12,rep,name=conditions"`
+
+	// The time when the pods of the restarting Job are recreated, according
+	// to its restartBackoff.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty" protobuf:"bytes,13,opt,name=nextRetryTime"`
+
+	// The replicas of the tasks when the pods of the Job were last synced,
+	// which are compared with the tasks to detect scaling.
+	// +optional
+	TaskReplicas map[string]int32 `json:"taskReplicas,omitempty" protobuf:"bytes,14,rep,name=taskReplicas"`
+}
+
+// JobConditionType is the type of JobCondition.
+type JobConditionType string
+
+const (
+	// JobActionPending means the action of a policy with timeout will be
+	// taken at the deadline, if the event that triggered it persists.
+	JobActionPending JobConditionType = "ActionPending"
+)
+
+// JobCondition contains details of a condition of the job.
+type JobCondition struct {
+	// Type of the condition.
+	Type JobConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
+
+	// The pending action.
+	// +optional
+	Action Action `json:"action,omitempty" protobuf:"bytes,2,opt,name=action"`
+
+	// The event, task and pod which triggered the action.
+	// +optional
+	Event Event `json:"event,omitempty" protobuf:"bytes,3,opt,name=event"`
+	// +optional
+	TaskName string `json:"taskName,omitempty" protobuf:"bytes,4,opt,name=taskName"`
+	// +optional
+	PodName string `json:"podName,omitempty" protobuf:"bytes,5,opt,name=podName"`
+
+	// The time when the action will be taken.
+	// +optional
+	Deadline *metav1.Time `json:"deadline,omitempty" protobuf:"bytes,6,opt,name=deadline"`
+
+	// Last time the condition transit from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,7,opt,name=lastTransitionTime"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
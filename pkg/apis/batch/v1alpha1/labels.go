@@ -29,4 +29,6 @@ const (
 	JobVersion = "volcano.sh/job-version"
 	// JobTypeKey job type key used in labels
 	JobTypeKey = "volcano.sh/job-type"
+	// CronJobNameKey cron job name key used in labels of the jobs created by CronJob
+	CronJobNameKey = "volcano.sh/cronjob-name"
 )
@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JobFlow defines a set of volcano jobs and the dependencies between them
+type JobFlow struct {
+	metav1.TypeMeta `json:",inline"`
+
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Specification of the desired behavior of the job flow
+	// +optional
+	Spec JobFlowSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+
+	// Current status of JobFlow
+	// +optional
+	Status JobFlowStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// JobFlowSpec describes the jobs of the flow and when they will be created
+type JobFlowSpec struct {
+	// Flows specifies the jobs in the flow and the dependencies between them
+	Flows []Flow `json:"flows,omitempty" protobuf:"bytes,1,rep,name=flows"`
+}
+
+// Flow describes one job in the flow
+type Flow struct {
+	// Name specifies the name of the flow, which is unique in the JobFlow;
+	// the Job created for it is named as "<jobflow name>-<flow name>".
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// Template specifies the Job that will be created for this flow
+	Template batch.JobSpec `json:"template" protobuf:"bytes,2,opt,name=template"`
+
+	// Specifies the flows that this flow depends on; the Job of this flow
+	// is created only after the Jobs of depended flows reach the specified phase.
+	// +optional
+	DependsOn *DependsOn `json:"dependsOn,omitempty" protobuf:"bytes,3,opt,name=dependsOn"`
+}
+
+// DependsOn represents the flows that a flow depends on
+type DependsOn struct {
+	// Targets specifies the names of the depended flows
+	Targets []string `json:"targets,omitempty" protobuf:"bytes,1,rep,name=targets"`
+
+	// Phase specifies the phase that the Jobs of depended flows should reach,
+	// one of "Running" or "Completed"; a completed Job is regarded as running.
+	// Defaults to Completed.
+	// +optional
+	Phase batch.JobPhase `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase"`
+}
+
+// JobFlowPhase defines the phase of the job flow
+type JobFlowPhase string
+
+const (
+	// Pending is the phase that no Job of the flow is created yet
+	Pending JobFlowPhase = "Pending"
+	// Running is the phase that some Jobs of the flow are created and not all finished
+	Running JobFlowPhase = "Running"
+	// Succeeded is the phase that all Jobs of the flow are completed
+	Succeeded JobFlowPhase = "Succeeded"
+	// Failed is the phase that some Job of the flow failed, or the flow is invalid
+	Failed JobFlowPhase = "Failed"
+)
+
+// JobFlowState contains details for the current state of the job flow
+type JobFlowState struct {
+	// The phase of JobFlow.
+	// +optional
+	Phase JobFlowPhase `json:"phase,omitempty" protobuf:"bytes,1,opt,name=phase"`
+
+	// Unique, one-word, CamelCase reason for the phase's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty" protobuf:"bytes,2,opt,name=reason"`
+
+	// Human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+
+	// Last time the condition transit from one phase to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
+}
+
+// JobStatus represents the status of the Job created for a flow
+type JobStatus struct {
+	// Name of the flow
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// Name of the Job created for the flow
+	JobName string `json:"jobName,omitempty" protobuf:"bytes,2,opt,name=jobName"`
+
+	// The phase of the Job, empty if the Job is not created yet
+	// +optional
+	Phase batch.JobPhase `json:"phase,omitempty" protobuf:"bytes,3,opt,name=phase"`
+}
+
+// JobFlowStatus represents the current status of a JobFlow
+type JobFlowStatus struct {
+	// Current state of JobFlow.
+	State JobFlowState `json:"state,omitempty" protobuf:"bytes,1,opt,name=state"`
+
+	// The status of Jobs of each flow
+	// +optional
+	JobStatuses []JobStatus `json:"jobStatuses,omitempty" protobuf:"bytes,2,rep,name=jobStatuses"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JobFlowList defines the list of job flows
+type JobFlowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []JobFlow `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
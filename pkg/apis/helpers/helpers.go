@@ -30,14 +30,21 @@ import (
 	vkbatchv1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
 	vkv1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
 	vkcorev1 "volcano.sh/volcano/pkg/apis/bus/v1alpha1"
+	vkflowv1 "volcano.sh/volcano/pkg/apis/flow/v1alpha1"
 )
 
 // JobKind  creates job GroupVersionKind
 var JobKind = vkbatchv1.SchemeGroupVersion.WithKind("Job")
 
+// CronJobKind  creates cron job GroupVersionKind
+var CronJobKind = vkbatchv1.SchemeGroupVersion.WithKind("CronJob")
+
 // CommandKind  creates command GroupVersionKind
 var CommandKind = vkcorev1.SchemeGroupVersion.WithKind("Command")
 
+// JobFlowKind  creates job flow GroupVersionKind
+var JobFlowKind = vkflowv1.SchemeGroupVersion.WithKind("JobFlow")
+
 // GetController  returns the controller uid
 func GetController(obj interface{}) types.UID {
 	accessor, err := meta.Accessor(obj)
@@ -192,6 +192,28 @@ type Queue struct {
 	Status QueueStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
 }
 
+// QueueState is state type of queue.
+type QueueState string
+
+const (
+	// QueueStateOpen indicates queue is open, new PodGroups could be submitted and scheduled.
+	QueueStateOpen QueueState = "Open"
+	// QueueStateClosed indicates queue is closed and has no PodGroups.
+	QueueStateClosed QueueState = "Closed"
+	// QueueStateClosing indicates queue is closed but still has PodGroups to drain;
+	// no new PodGroups in it could be scheduled.
+	QueueStateClosing QueueState = "Closing"
+)
+
+// IsOpen checks whether new PodGroups could be submitted to and scheduled in the queue.
+func (q *Queue) IsOpen() bool {
+	if q.Spec.State == QueueStateClosed {
+		return false
+	}
+
+	return q.Status.State == "" || q.Status.State == QueueStateOpen
+}
+
 // QueueStatus represents the status of Queue.
 type QueueStatus struct {
 	// The number of 'Unknonw' PodGroup in this queue.
@@ -200,12 +222,25 @@ type QueueStatus struct {
 	Pending int32 `json:"pending,omitempty" protobuf:"bytes,2,opt,name=pending"`
 	// The number of 'Running' PodGroup in this queue.
 	Running int32 `json:"running,omitempty" protobuf:"bytes,3,opt,name=running"`
+
+	// State is the current state of queue.
+	// +optional
+	State QueueState `json:"state,omitempty" protobuf:"bytes,4,opt,name=state"`
 }
 
 // QueueSpec represents the template of Queue.
 type QueueSpec struct {
 	Weight     int32           `json:"weight,omitempty" protobuf:"bytes,1,opt,name=weight"`
 	Capability v1.ResourceList `json:"capability,omitempty" protobuf:"bytes,2,opt,name=capability"`
+
+	// Parent is the name of the parent queue; the deserved resource of the
+	// parent queue is shared by its child queues according to their weight.
+	// +optional
+	Parent string `json:"parent,omitempty" protobuf:"bytes,3,opt,name=parent"`
+
+	// State is the desired state of queue, Open or Closed; defaults to Open.
+	// +optional
+	State QueueState `json:"state,omitempty" protobuf:"bytes,4,opt,name=state"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
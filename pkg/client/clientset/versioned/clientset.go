@@ -24,6 +24,7 @@ import (
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
 	batchv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/batch/v1alpha1"
 	busv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/bus/v1alpha1"
+	flowv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/flow/v1alpha1"
 	schedulingv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/scheduling/v1alpha1"
 )
 
@@ -35,6 +36,9 @@ type Interface interface {
 	BusV1alpha1() busv1alpha1.BusV1alpha1Interface
 	// Deprecated: please explicitly pick a version if possible.
 	Bus() busv1alpha1.BusV1alpha1Interface
+	FlowV1alpha1() flowv1alpha1.FlowV1alpha1Interface
+	// Deprecated: please explicitly pick a version if possible.
+	Flow() flowv1alpha1.FlowV1alpha1Interface
 	SchedulingV1alpha1() schedulingv1alpha1.SchedulingV1alpha1Interface
 	// Deprecated: please explicitly pick a version if possible.
 	Scheduling() schedulingv1alpha1.SchedulingV1alpha1Interface
@@ -46,6 +50,7 @@ type Clientset struct {
 	*discovery.DiscoveryClient
 	batchV1alpha1      *batchv1alpha1.BatchV1alpha1Client
 	busV1alpha1        *busv1alpha1.BusV1alpha1Client
+	flowV1alpha1       *flowv1alpha1.FlowV1alpha1Client
 	schedulingV1alpha1 *schedulingv1alpha1.SchedulingV1alpha1Client
 }
 
@@ -71,6 +76,17 @@ func (c *Clientset) Bus() busv1alpha1.BusV1alpha1Interface {
 	return c.busV1alpha1
 }
 
+// FlowV1alpha1 retrieves the FlowV1alpha1Client
+func (c *Clientset) FlowV1alpha1() flowv1alpha1.FlowV1alpha1Interface {
+	return c.flowV1alpha1
+}
+
+// Deprecated: Flow retrieves the default version of FlowClient.
+// Please explicitly pick a version.
+func (c *Clientset) Flow() flowv1alpha1.FlowV1alpha1Interface {
+	return c.flowV1alpha1
+}
+
 // SchedulingV1alpha1 retrieves the SchedulingV1alpha1Client
 func (c *Clientset) SchedulingV1alpha1() schedulingv1alpha1.SchedulingV1alpha1Interface {
 	return c.schedulingV1alpha1
@@ -106,6 +122,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.flowV1alpha1, err = flowv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	cs.schedulingV1alpha1, err = schedulingv1alpha1.NewForConfig(&configShallowCopy)
 	if err != nil {
 		return nil, err
@@ -124,6 +144,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.batchV1alpha1 = batchv1alpha1.NewForConfigOrDie(c)
 	cs.busV1alpha1 = busv1alpha1.NewForConfigOrDie(c)
+	cs.flowV1alpha1 = flowv1alpha1.NewForConfigOrDie(c)
 	cs.schedulingV1alpha1 = schedulingv1alpha1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
@@ -135,6 +156,7 @@ func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.batchV1alpha1 = batchv1alpha1.New(c)
 	cs.busV1alpha1 = busv1alpha1.New(c)
+	cs.flowV1alpha1 = flowv1alpha1.New(c)
 	cs.schedulingV1alpha1 = schedulingv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
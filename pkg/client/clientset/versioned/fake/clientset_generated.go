@@ -29,6 +29,8 @@ import (
 	fakebatchv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/batch/v1alpha1/fake"
 	busv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/bus/v1alpha1"
 	fakebusv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/bus/v1alpha1/fake"
+	flowv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/flow/v1alpha1"
+	fakeflowv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/flow/v1alpha1/fake"
 	schedulingv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/scheduling/v1alpha1"
 	fakeschedulingv1alpha1 "volcano.sh/volcano/pkg/client/clientset/versioned/typed/scheduling/v1alpha1/fake"
 )
@@ -95,6 +97,16 @@ func (c *Clientset) Bus() busv1alpha1.BusV1alpha1Interface {
 	return &fakebusv1alpha1.FakeBusV1alpha1{Fake: &c.Fake}
 }
 
+// FlowV1alpha1 retrieves the FlowV1alpha1Client
+func (c *Clientset) FlowV1alpha1() flowv1alpha1.FlowV1alpha1Interface {
+	return &fakeflowv1alpha1.FakeFlowV1alpha1{Fake: &c.Fake}
+}
+
+// Flow retrieves the FlowV1alpha1Client
+func (c *Clientset) Flow() flowv1alpha1.FlowV1alpha1Interface {
+	return &fakeflowv1alpha1.FakeFlowV1alpha1{Fake: &c.Fake}
+}
+
 // SchedulingV1alpha1 retrieves the SchedulingV1alpha1Client
 func (c *Clientset) SchedulingV1alpha1() schedulingv1alpha1.SchedulingV1alpha1Interface {
 	return &fakeschedulingv1alpha1.FakeSchedulingV1alpha1{Fake: &c.Fake}
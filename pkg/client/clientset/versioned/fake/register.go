@@ -26,6 +26,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	batchv1alpha1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
 	busv1alpha1 "volcano.sh/volcano/pkg/apis/bus/v1alpha1"
+	flowv1alpha1 "volcano.sh/volcano/pkg/apis/flow/v1alpha1"
 	schedulingv1alpha1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
 )
 
@@ -35,6 +36,7 @@ var parameterCodec = runtime.NewParameterCodec(scheme)
 var localSchemeBuilder = runtime.SchemeBuilder{
 	batchv1alpha1.AddToScheme,
 	busv1alpha1.AddToScheme,
+	flowv1alpha1.AddToScheme,
 	schedulingv1alpha1.AddToScheme,
 }
 
@@ -27,6 +27,7 @@ import (
 
 type BatchV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	CronJobsGetter
 	JobsGetter
 }
 
@@ -35,6 +36,10 @@ type BatchV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *BatchV1alpha1Client) CronJobs(namespace string) CronJobInterface {
+	return newCronJobs(c, namespace)
+}
+
 func (c *BatchV1alpha1Client) Jobs(namespace string) JobInterface {
 	return newJobs(c, namespace)
 }
@@ -30,6 +30,7 @@ import (
 	versioned "volcano.sh/volcano/pkg/client/clientset/versioned"
 	batch "volcano.sh/volcano/pkg/client/informers/externalversions/batch"
 	bus "volcano.sh/volcano/pkg/client/informers/externalversions/bus"
+	flow "volcano.sh/volcano/pkg/client/informers/externalversions/flow"
 	internalinterfaces "volcano.sh/volcano/pkg/client/informers/externalversions/internalinterfaces"
 	scheduling "volcano.sh/volcano/pkg/client/informers/externalversions/scheduling"
 )
@@ -176,6 +177,7 @@ type SharedInformerFactory interface {
 
 	Batch() batch.Interface
 	Bus() bus.Interface
+	Flow() flow.Interface
 	Scheduling() scheduling.Interface
 }
 
@@ -187,6 +189,10 @@ func (f *sharedInformerFactory) Bus() bus.Interface {
 	return bus.New(f, f.namespace, f.tweakListOptions)
 }
 
+func (f *sharedInformerFactory) Flow() flow.Interface {
+	return flow.New(f, f.namespace, f.tweakListOptions)
+}
+
 func (f *sharedInformerFactory) Scheduling() scheduling.Interface {
 	return scheduling.New(f, f.namespace, f.tweakListOptions)
 }
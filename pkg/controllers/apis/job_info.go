@@ -144,6 +144,13 @@ type Request struct {
 	ExitCode   int32
 	Action     v1alpha1.Action
 	JobVersion int32
+
+	// Pod is the pod which failed or was evicted, its termination states
+	// are matched by the exit codes and reasons of policies.
+	Pod *v1.Pod
+	// Delayed is set if the request is requeued for the timeout of the
+	// policy, the action is taken only if the event persists.
+	Delayed bool
 }
 
 //String function returns the request in string format
@@ -0,0 +1,448 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	batch "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/apis/helpers"
+	vkclientset "volcano.sh/volcano/pkg/client/clientset/versioned"
+	vkscheme "volcano.sh/volcano/pkg/client/clientset/versioned/scheme"
+	vkinformerfactory "volcano.sh/volcano/pkg/client/informers/externalversions"
+	vkbatchinformer "volcano.sh/volcano/pkg/client/informers/externalversions/batch/v1alpha1"
+	vkbatchlister "volcano.sh/volcano/pkg/client/listers/batch/v1alpha1"
+)
+
+const (
+	// syncPeriod is the interval to check the schedules of all CronJobs.
+	syncPeriod = 10 * time.Second
+
+	// maxMissedSchedules is the max number of missed schedules to look back;
+	// the CronJob is not started if there are more, e.g. the clock is skewed.
+	maxMissedSchedules = 100
+
+	defaultSuccessfulJobsHistoryLimit = 3
+	defaultFailedJobsHistoryLimit     = 1
+)
+
+// Controller creates volcano Jobs on the schedules of CronJobs.
+type Controller struct {
+	kubeClient kubernetes.Interface
+	vkClient   vkclientset.Interface
+
+	// informer
+	cronJobInformer vkbatchinformer.CronJobInformer
+	jobInformer     vkbatchinformer.JobInformer
+
+	// cronJobLister
+	cronJobLister vkbatchlister.CronJobLister
+	cronJobSynced cache.InformerSynced
+
+	// jobLister
+	jobLister vkbatchlister.JobLister
+	jobSynced cache.InformerSynced
+
+	// CronJob Event recorder
+	recorder record.EventRecorder
+
+	// now returns the current time, which is replaced in tests.
+	now func() time.Time
+}
+
+// NewCronJobController creates a CronJobController
+func NewCronJobController(kubeClient kubernetes.Interface, vkClient vkclientset.Interface) *Controller {
+	factory := vkinformerfactory.NewSharedInformerFactory(vkClient, 0)
+	cronJobInformer := factory.Batch().V1alpha1().CronJobs()
+	jobInformer := factory.Batch().V1alpha1().Jobs()
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(glog.Infof)
+	eventBroadcaster.StartRecordingToSink(&corev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(vkscheme.Scheme, v1.EventSource{Component: "vc-controller"})
+
+	return &Controller{
+		kubeClient: kubeClient,
+		vkClient:   vkClient,
+
+		cronJobInformer: cronJobInformer,
+		jobInformer:     jobInformer,
+
+		cronJobLister: cronJobInformer.Lister(),
+		cronJobSynced: cronJobInformer.Informer().HasSynced,
+
+		jobLister: jobInformer.Lister(),
+		jobSynced: jobInformer.Informer().HasSynced,
+
+		recorder: recorder,
+		now:      time.Now,
+	}
+}
+
+// Run starts CronJobController
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	go c.cronJobInformer.Informer().Run(stopCh)
+	go c.jobInformer.Informer().Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, c.cronJobSynced, c.jobSynced) {
+		glog.Errorf("unable to sync caches for cron job controller")
+		return
+	}
+
+	go wait.Until(c.syncAll, syncPeriod, stopCh)
+	glog.Infof("CronJobController is running ...... ")
+}
+
+// syncAll checks all CronJobs and their Jobs.
+func (c *Controller) syncAll() {
+	cronJobs, err := c.cronJobLister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Failed to list CronJobs: %v", err)
+		return
+	}
+
+	jobs, err := c.jobLister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Failed to list Jobs: %v", err)
+		return
+	}
+
+	jobsByCronJob := map[types.UID][]*batch.Job{}
+	for _, job := range jobs {
+		if !helpers.ControlledBy(job, helpers.CronJobKind) {
+			continue
+		}
+		uid := helpers.GetController(job)
+		jobsByCronJob[uid] = append(jobsByCronJob[uid], job)
+	}
+
+	for _, cronJob := range cronJobs {
+		if err := c.syncCronJob(cronJob.DeepCopy(), jobsByCronJob[cronJob.UID]); err != nil {
+			glog.Errorf("Failed to sync CronJob %s/%s: %v", cronJob.Namespace, cronJob.Name, err)
+		}
+	}
+}
+
+// syncCronJob reconciles the active Jobs of the CronJob, starts a new Job if
+// it is time to, and cleans up finished Jobs beyond the history limits.
+func (c *Controller) syncCronJob(cronJob *batch.CronJob, jobs []*batch.Job) error {
+	glog.V(4).Infof("Begin sync CronJob %s/%s", cronJob.Namespace, cronJob.Name)
+	defer glog.V(4).Infof("End sync CronJob %s/%s", cronJob.Namespace, cronJob.Name)
+
+	now := c.now()
+
+	if changed := c.updateActive(cronJob, jobs); changed {
+		if err := c.updateStatus(cronJob); err != nil {
+			return err
+		}
+	}
+
+	if err := c.cleanupFinishedJobs(cronJob, jobs); err != nil {
+		return err
+	}
+
+	if cronJob.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		glog.V(4).Infof("CronJob %s/%s is suspended, skip scheduling", cronJob.Namespace, cronJob.Name)
+		return nil
+	}
+
+	scheduledTime, err := mostRecentScheduleTime(cronJob, now)
+	if err != nil {
+		c.recorder.Eventf(cronJob, v1.EventTypeWarning, "FailedNeedsStart", "Cannot determine if job needs to be started: %v", err)
+		return nil
+	}
+	if scheduledTime == nil {
+		return nil
+	}
+
+	if deadline := cronJob.Spec.StartingDeadlineSeconds; deadline != nil &&
+		scheduledTime.Add(time.Duration(*deadline)*time.Second).Before(now) {
+		glog.V(3).Infof("Missed starting window of CronJob %s/%s at %v", cronJob.Namespace, cronJob.Name, scheduledTime)
+		c.recorder.Eventf(cronJob, v1.EventTypeWarning, "MissSchedule", "Missed scheduled time to start a job: %s", scheduledTime.Format(time.RFC1123Z))
+		return nil
+	}
+
+	switch cronJob.Spec.ConcurrencyPolicy {
+	case batch.ForbidConcurrent:
+		if len(cronJob.Status.Active) != 0 {
+			glog.V(3).Infof("Not starting Job of CronJob %s/%s because prior execution is still running and concurrency policy is Forbid",
+				cronJob.Namespace, cronJob.Name)
+			return nil
+		}
+	case batch.ReplaceConcurrent:
+		for _, ref := range cronJob.Status.Active {
+			if err := c.deleteJob(cronJob, ref.Name); err != nil {
+				return err
+			}
+		}
+		cronJob.Status.Active = nil
+	}
+
+	job := newJobFromTemplate(cronJob, *scheduledTime)
+	newJob, err := c.vkClient.BatchV1alpha1().Jobs(job.Namespace).Create(job)
+	if err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			c.recorder.Eventf(cronJob, v1.EventTypeWarning, "FailedCreate", "Error creating job: %v", err)
+			return err
+		}
+		// The Job was created in previous sync, but the status was failed to update.
+		if newJob, err = c.vkClient.BatchV1alpha1().Jobs(job.Namespace).Get(job.Name, metav1.GetOptions{}); err != nil {
+			return err
+		}
+	} else {
+		glog.V(3).Infof("Created Job <%s/%s> of CronJob %s", job.Namespace, job.Name, cronJob.Name)
+		c.recorder.Eventf(cronJob, v1.EventTypeNormal, "SuccessfulCreate", "Created job %v", job.Name)
+	}
+
+	if !inActiveList(cronJob, newJob.UID) {
+		cronJob.Status.Active = append(cronJob.Status.Active, jobReference(newJob))
+	}
+	cronJob.Status.LastScheduleTime = &metav1.Time{Time: *scheduledTime}
+
+	return c.updateStatus(cronJob)
+}
+
+// updateActive adds the unfinished Jobs to and removes the finished or
+// deleted Jobs from the active list of CronJob, returns whether it changed.
+func (c *Controller) updateActive(cronJob *batch.CronJob, jobs []*batch.Job) bool {
+	changed := false
+	existing := map[types.UID]bool{}
+
+	for _, job := range jobs {
+		// The Jobs being deleted, e.g. replaced ones, are not active any more.
+		if job.DeletionTimestamp != nil {
+			continue
+		}
+		existing[job.UID] = true
+
+		active := inActiveList(cronJob, job.UID)
+		finished := isJobFinished(job)
+		switch {
+		case !active && !finished:
+			cronJob.Status.Active = append(cronJob.Status.Active, jobReference(job))
+			changed = true
+		case active && finished:
+			deleteFromActiveList(cronJob, job.UID)
+			c.recorder.Eventf(cronJob, v1.EventTypeNormal, "SawCompletedJob", "Saw completed job: %s, status: %s",
+				job.Name, job.Status.State.Phase)
+			changed = true
+		}
+	}
+
+	// The Jobs which are removed from cluster should be removed from active list too.
+	for _, ref := range cronJob.Status.Active {
+		if !existing[ref.UID] {
+			deleteFromActiveList(cronJob, ref.UID)
+			c.recorder.Eventf(cronJob, v1.EventTypeNormal, "MissingJob", "Active job went missing: %v", ref.Name)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// cleanupFinishedJobs deletes the oldest finished Jobs beyond the history limits.
+func (c *Controller) cleanupFinishedJobs(cronJob *batch.CronJob, jobs []*batch.Job) error {
+	successfulLimit := int32(defaultSuccessfulJobsHistoryLimit)
+	if cronJob.Spec.SuccessfulJobsHistoryLimit != nil {
+		successfulLimit = *cronJob.Spec.SuccessfulJobsHistoryLimit
+	}
+	failedLimit := int32(defaultFailedJobsHistoryLimit)
+	if cronJob.Spec.FailedJobsHistoryLimit != nil {
+		failedLimit = *cronJob.Spec.FailedJobsHistoryLimit
+	}
+
+	var successfulJobs, failedJobs []*batch.Job
+	for _, job := range jobs {
+		if !isJobFinished(job) || job.DeletionTimestamp != nil {
+			continue
+		}
+		if job.Status.State.Phase == batch.Completed {
+			successfulJobs = append(successfulJobs, job)
+		} else {
+			failedJobs = append(failedJobs, job)
+		}
+	}
+
+	for _, history := range []struct {
+		jobs  []*batch.Job
+		limit int32
+	}{
+		{successfulJobs, successfulLimit},
+		{failedJobs, failedLimit},
+	} {
+		if int32(len(history.jobs)) <= history.limit {
+			continue
+		}
+
+		sort.Slice(history.jobs, func(i, j int) bool {
+			return history.jobs[i].CreationTimestamp.Before(&history.jobs[j].CreationTimestamp)
+		})
+		for _, job := range history.jobs[:int32(len(history.jobs))-history.limit] {
+			if err := c.deleteJob(cronJob, job.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) deleteJob(cronJob *batch.CronJob, name string) error {
+	propagation := metav1.DeletePropagationBackground
+	err := c.vkClient.BatchV1alpha1().Jobs(cronJob.Namespace).Delete(name, &metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		c.recorder.Eventf(cronJob, v1.EventTypeWarning, "FailedDelete", "Deleted job: %v", err)
+		return err
+	}
+
+	glog.V(3).Infof("Deleted Job <%s/%s> of CronJob %s", cronJob.Namespace, name, cronJob.Name)
+	c.recorder.Eventf(cronJob, v1.EventTypeNormal, "SuccessfulDelete", "Deleted job %v", name)
+	return nil
+}
+
+func (c *Controller) updateStatus(cronJob *batch.CronJob) error {
+	newCronJob, err := c.vkClient.BatchV1alpha1().CronJobs(cronJob.Namespace).UpdateStatus(cronJob)
+	if err != nil {
+		glog.Errorf("Failed to update status of CronJob %s/%s: %v", cronJob.Namespace, cronJob.Name, err)
+		return err
+	}
+
+	cronJob.ResourceVersion = newCronJob.ResourceVersion
+	return nil
+}
+
+// mostRecentScheduleTime returns the latest schedule time of the CronJob
+// between its last schedule time and now, nil if there is no such time.
+func mostRecentScheduleTime(cronJob *batch.CronJob, now time.Time) (*time.Time, error) {
+	schedule, err := ParseSchedule(cronJob.Spec.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("unparseable schedule: %s : %v", cronJob.Spec.Schedule, err)
+	}
+
+	earliestTime := cronJob.CreationTimestamp.Time
+	if cronJob.Status.LastScheduleTime != nil {
+		earliestTime = cronJob.Status.LastScheduleTime.Time
+	}
+	if deadline := cronJob.Spec.StartingDeadlineSeconds; deadline != nil {
+		// The schedules before the deadline will never be started.
+		if windowStart := now.Add(-time.Duration(*deadline) * time.Second); windowStart.After(earliestTime) {
+			earliestTime = windowStart
+		}
+	}
+	if earliestTime.After(now) {
+		return nil, nil
+	}
+
+	var mostRecent *time.Time
+	missed := 0
+	for t := schedule.Next(earliestTime); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		scheduledTime := t
+		mostRecent = &scheduledTime
+
+		missed++
+		if missed > maxMissedSchedules {
+			return nil, fmt.Errorf("too many missed start times (> %d), check clock skew", maxMissedSchedules)
+		}
+	}
+
+	return mostRecent, nil
+}
+
+// newJobFromTemplate creates the Job of CronJob for the scheduled time; the
+// Job name is deterministic so that the Job is not created twice.
+func newJobFromTemplate(cronJob *batch.CronJob, scheduledTime time.Time) *batch.Job {
+	template := cronJob.Spec.JobTemplate.DeepCopy()
+
+	jobLabels := template.Labels
+	if jobLabels == nil {
+		jobLabels = map[string]string{}
+	}
+	jobLabels[batch.CronJobNameKey] = cronJob.Name
+
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%d", cronJob.Name, scheduledTime.Unix()/60),
+			Namespace:   cronJob.Namespace,
+			Labels:      jobLabels,
+			Annotations: template.Annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, helpers.CronJobKind),
+			},
+		},
+		Spec: template.Spec,
+	}
+}
+
+func isJobFinished(job *batch.Job) bool {
+	switch job.Status.State.Phase {
+	case batch.Completed, batch.Failed, batch.Terminated, batch.Aborted:
+		return true
+	}
+	return false
+}
+
+func jobReference(job *batch.Job) v1.ObjectReference {
+	return v1.ObjectReference{
+		Kind:            helpers.JobKind.Kind,
+		APIVersion:      helpers.JobKind.GroupVersion().String(),
+		Namespace:       job.Namespace,
+		Name:            job.Name,
+		UID:             job.UID,
+		ResourceVersion: job.ResourceVersion,
+	}
+}
+
+func inActiveList(cronJob *batch.CronJob, uid types.UID) bool {
+	for _, ref := range cronJob.Status.Active {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func deleteFromActiveList(cronJob *batch.CronJob, uid types.UID) {
+	var active []v1.ObjectReference
+	for _, ref := range cronJob.Status.Active {
+		if ref.UID != uid {
+			active = append(active, ref)
+		}
+	}
+	cronJob.Status.Active = active
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes/fake"
+
+	batch "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	vkclient "volcano.sh/volcano/pkg/client/clientset/versioned/fake"
+)
+
+func newCronJob(policy batch.ConcurrencyPolicy, created time.Time) *batch.CronJob {
+	return &batch.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nightly",
+			Namespace:         "test",
+			UID:               "nightly-uid",
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Spec: batch.CronJobSpec{
+			Schedule:          "0 2 * * *",
+			ConcurrencyPolicy: policy,
+		},
+	}
+}
+
+func newCronJobJob(cronJob *batch.CronJob, scheduledTime time.Time, phase batch.JobPhase) *batch.Job {
+	job := newJobFromTemplate(cronJob, scheduledTime)
+	job.UID = types.UID(job.Name)
+	job.CreationTimestamp = metav1.Time{Time: scheduledTime}
+	job.Status.State.Phase = phase
+	return job
+}
+
+func TestMostRecentScheduleTime(t *testing.T) {
+	created := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	deadline := int64(60)
+
+	testCases := []struct {
+		Name      string
+		Last      *time.Time
+		Now       time.Time
+		Deadline  *int64
+		Expect    *time.Time
+		ExpectErr bool
+	}{
+		{
+			Name: "not yet scheduled",
+			Now:  time.Date(2019, 6, 1, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:   "scheduled",
+			Now:    time.Date(2019, 6, 1, 2, 0, 30, 0, time.UTC),
+			Expect: timePtr(time.Date(2019, 6, 1, 2, 0, 0, 0, time.UTC)),
+		},
+		{
+			Name:   "most recent of missed schedules",
+			Now:    time.Date(2019, 6, 3, 3, 0, 0, 0, time.UTC),
+			Expect: timePtr(time.Date(2019, 6, 3, 2, 0, 0, 0, time.UTC)),
+		},
+		{
+			Name: "already scheduled",
+			Last: timePtr(time.Date(2019, 6, 1, 2, 0, 0, 0, time.UTC)),
+			Now:  time.Date(2019, 6, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:     "missed starting deadline",
+			Now:      time.Date(2019, 6, 1, 3, 0, 0, 0, time.UTC),
+			Deadline: &deadline,
+		},
+	}
+
+	for _, testCase := range testCases {
+		cronJob := newCronJob(batch.AllowConcurrent, created)
+		cronJob.Spec.StartingDeadlineSeconds = testCase.Deadline
+		if testCase.Last != nil {
+			cronJob.Status.LastScheduleTime = &metav1.Time{Time: *testCase.Last}
+		}
+
+		scheduledTime, err := mostRecentScheduleTime(cronJob, testCase.Now)
+		if testCase.ExpectErr != (err != nil) {
+			t.Errorf("%s: expected error %t, but got %v", testCase.Name, testCase.ExpectErr, err)
+		}
+		switch {
+		case testCase.Expect == nil && scheduledTime != nil:
+			t.Errorf("%s: expected no schedule time, but got %v", testCase.Name, scheduledTime)
+		case testCase.Expect != nil && (scheduledTime == nil || !scheduledTime.Equal(*testCase.Expect)):
+			t.Errorf("%s: expected schedule time %v, but got %v", testCase.Name, testCase.Expect, scheduledTime)
+		}
+	}
+}
+
+func TestSyncCronJob(t *testing.T) {
+	created := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	yesterday := time.Date(2019, 6, 1, 2, 0, 0, 0, time.UTC)
+	today := time.Date(2019, 6, 2, 2, 0, 0, 0, time.UTC)
+	now := today.Add(time.Minute)
+
+	testCases := []struct {
+		Name         string
+		Policy       batch.ConcurrencyPolicy
+		Suspend      bool
+		Jobs         []batch.JobPhase
+		Deleting     bool
+		ExpectJobs   []string
+		ExpectActive int
+	}{
+		{
+			Name:         "create job on schedule",
+			Policy:       batch.AllowConcurrent,
+			ExpectJobs:   []string{newJobFromTemplate(newCronJob("", created), today).Name},
+			ExpectActive: 1,
+		},
+		{
+			Name:         "suspended",
+			Policy:       batch.AllowConcurrent,
+			Suspend:      true,
+			ExpectJobs:   nil,
+			ExpectActive: 0,
+		},
+		{
+			Name:   "allow concurrent jobs",
+			Policy: batch.AllowConcurrent,
+			Jobs:   []batch.JobPhase{batch.Running},
+			ExpectJobs: []string{
+				newJobFromTemplate(newCronJob("", created), yesterday).Name,
+				newJobFromTemplate(newCronJob("", created), today).Name,
+			},
+			ExpectActive: 2,
+		},
+		{
+			Name:         "forbid concurrent jobs",
+			Policy:       batch.ForbidConcurrent,
+			Jobs:         []batch.JobPhase{batch.Running},
+			ExpectJobs:   []string{newJobFromTemplate(newCronJob("", created), yesterday).Name},
+			ExpectActive: 1,
+		},
+		{
+			Name:         "replace concurrent jobs",
+			Policy:       batch.ReplaceConcurrent,
+			Jobs:         []batch.JobPhase{batch.Running},
+			ExpectJobs:   []string{newJobFromTemplate(newCronJob("", created), today).Name},
+			ExpectActive: 1,
+		},
+		{
+			Name:         "previous job being deleted",
+			Policy:       batch.ForbidConcurrent,
+			Jobs:         []batch.JobPhase{batch.Running},
+			Deleting:     true,
+			ExpectJobs:   []string{newJobFromTemplate(newCronJob("", created), yesterday).Name, newJobFromTemplate(newCronJob("", created), today).Name},
+			ExpectActive: 1,
+		},
+		{
+			Name:         "previous job finished",
+			Policy:       batch.ForbidConcurrent,
+			Jobs:         []batch.JobPhase{batch.Completed},
+			ExpectJobs:   []string{newJobFromTemplate(newCronJob("", created), yesterday).Name, newJobFromTemplate(newCronJob("", created), today).Name},
+			ExpectActive: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		cronJob := newCronJob(testCase.Policy, created)
+		cronJob.Spec.Suspend = &testCase.Suspend
+
+		vkClient := vkclient.NewSimpleClientset()
+		c := NewCronJobController(kubeclient.NewSimpleClientset(), vkClient)
+		c.now = func() time.Time { return now }
+
+		var jobs []*batch.Job
+		for _, phase := range testCase.Jobs {
+			job := newCronJobJob(cronJob, yesterday, phase)
+			if testCase.Deleting {
+				job.DeletionTimestamp = &metav1.Time{Time: now}
+			}
+			if _, err := vkClient.BatchV1alpha1().Jobs(job.Namespace).Create(job); err != nil {
+				t.Fatalf("%s: failed to create job: %v", testCase.Name, err)
+			}
+			jobs = append(jobs, job)
+			cronJob.Status.LastScheduleTime = &metav1.Time{Time: yesterday}
+		}
+
+		if _, err := vkClient.BatchV1alpha1().CronJobs(cronJob.Namespace).Create(cronJob); err != nil {
+			t.Fatalf("%s: failed to create cron job: %v", testCase.Name, err)
+		}
+
+		if err := c.syncCronJob(cronJob, jobs); err != nil {
+			t.Errorf("%s: failed to sync cron job: %v", testCase.Name, err)
+			continue
+		}
+
+		for _, scheduledTime := range []time.Time{yesterday, today} {
+			name := newJobFromTemplate(cronJob, scheduledTime).Name
+			_, err := vkClient.BatchV1alpha1().Jobs(cronJob.Namespace).Get(name, metav1.GetOptions{})
+			if expected := contains(testCase.ExpectJobs, name); expected != (err == nil) {
+				t.Errorf("%s: expected job %s exists %t, but got error %v", testCase.Name, name, expected, err)
+			}
+		}
+
+		if len(cronJob.Status.Active) != testCase.ExpectActive {
+			t.Errorf("%s: expected %d active jobs, but got %v", testCase.Name, testCase.ExpectActive, cronJob.Status.Active)
+		}
+	}
+}
+
+func TestCleanupFinishedJobs(t *testing.T) {
+	created := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	cronJob := newCronJob(batch.AllowConcurrent, created)
+
+	vkClient := vkclient.NewSimpleClientset()
+	c := NewCronJobController(kubeclient.NewSimpleClientset(), vkClient)
+
+	var jobs []*batch.Job
+	for day := 1; day <= 5; day++ {
+		phase := batch.Completed
+		switch day {
+		case 2:
+			phase = batch.Failed
+		case 4:
+			phase = batch.Aborted
+		}
+		job := newCronJobJob(cronJob, time.Date(2019, 6, day, 2, 0, 0, 0, time.UTC), phase)
+		if _, err := vkClient.BatchV1alpha1().Jobs(job.Namespace).Create(job); err != nil {
+			t.Fatalf("failed to create job: %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := c.cleanupFinishedJobs(cronJob, jobs); err != nil {
+		t.Fatalf("failed to clean up jobs: %v", err)
+	}
+
+	// Keeps the 3 completed jobs and the latest failed (aborted) job.
+	for day, job := range jobs {
+		_, err := vkClient.BatchV1alpha1().Jobs(job.Namespace).Get(job.Name, metav1.GetOptions{})
+		if expected := day != 1; expected != (err == nil) {
+			t.Errorf("expected job %s exists %t, but got error %v", job.Name, expected, err)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	testCases := []struct {
+		Spec      string
+		ExpectErr bool
+	}{
+		{Spec: "* * * * *"},
+		{Spec: "*/15 2-4 * * MON-FRI"},
+		{Spec: "0 0 1,15 jan,jul ?"},
+		{Spec: "0 0 * * 7"},
+		{Spec: "@daily"},
+		{Spec: "", ExpectErr: true},
+		{Spec: "* * * *", ExpectErr: true},
+		{Spec: "60 * * * *", ExpectErr: true},
+		{Spec: "* * 0 * *", ExpectErr: true},
+		{Spec: "5-1 * * * *", ExpectErr: true},
+		{Spec: "*/0 * * * *", ExpectErr: true},
+		{Spec: "* * * foo *", ExpectErr: true},
+	}
+
+	for _, testCase := range testCases {
+		_, err := ParseSchedule(testCase.Spec)
+		if testCase.ExpectErr != (err != nil) {
+			t.Errorf("%q: expected error %t, but got %v", testCase.Spec, testCase.ExpectErr, err)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	testCases := []struct {
+		Spec   string
+		From   string
+		Expect string
+	}{
+		{"* * * * *", "2019-06-01T10:20:30Z", "2019-06-01T10:21:00Z"},
+		{"*/15 * * * *", "2019-06-01T10:20:00Z", "2019-06-01T10:30:00Z"},
+		{"0 2 * * *", "2019-06-01T10:20:00Z", "2019-06-02T02:00:00Z"},
+		{"@hourly", "2019-06-01T10:00:00Z", "2019-06-01T11:00:00Z"},
+		{"@monthly", "2019-12-15T00:00:00Z", "2020-01-01T00:00:00Z"},
+		// 2019-06-01 is Saturday.
+		{"0 9 * * MON-FRI", "2019-06-01T10:00:00Z", "2019-06-03T09:00:00Z"},
+		{"0 0 * * 7", "2019-06-01T10:00:00Z", "2019-06-02T00:00:00Z"},
+		// Either day of month or day of week matches if both are restricted.
+		{"0 0 13 * 5", "2019-06-01T10:00:00Z", "2019-06-07T00:00:00Z"},
+		{"0 0 29 2 *", "2019-03-01T00:00:00Z", "2020-02-29T00:00:00Z"},
+	}
+
+	for _, testCase := range testCases {
+		schedule, err := ParseSchedule(testCase.Spec)
+		if err != nil {
+			t.Errorf("%q: failed to parse schedule: %v", testCase.Spec, err)
+			continue
+		}
+
+		from, _ := time.Parse(time.RFC3339, testCase.From)
+		expect, _ := time.Parse(time.RFC3339, testCase.Expect)
+		if next := schedule.Next(from); !next.Equal(expect) {
+			t.Errorf("%q: expected next of %s to be %s, but got %s", testCase.Spec, testCase.From, testCase.Expect, next)
+		}
+	}
+}
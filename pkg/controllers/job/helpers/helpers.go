@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"k8s.io/api/core/v1"
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
+	"volcano.sh/volcano/pkg/apis/batch/v1alpha1"
 	"volcano.sh/volcano/pkg/controllers/apis"
 )
 
@@ -67,3 +69,63 @@ func MakeVolumeClaimName(jobName string) string {
 func GetJobKeyByReq(req *apis.Request) string {
 	return fmt.Sprintf("%s/%s", req.Namespace, req.JobName)
 }
+
+// MatchExitCode returns whether the exit code matches the expression in
+// LifecyclePolicy.ExitCodes, which is an exit code "3", a range "10-20",
+// or "NonZero".
+func MatchExitCode(expr string, code int32) (bool, error) {
+	if expr == v1alpha1.NonZeroExitCode {
+		return code != 0, nil
+	}
+
+	bounds := strings.SplitN(expr, "-", 2)
+	min, err := strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 32)
+	if err != nil {
+		return false, fmt.Errorf("invalid exit code %q", expr)
+	}
+	max := min
+	if len(bounds) == 2 {
+		if max, err = strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 32); err != nil || max < min {
+			return false, fmt.Errorf("invalid exit code range %q", expr)
+		}
+	}
+
+	return int64(code) >= min && int64(code) <= max, nil
+}
+
+// FindDependencyCycle returns the first of names from which a circular
+// dependency is reachable, where dependsOn maps each name to the names it
+// depends on; ok is false if there's no circular dependency.
+func FindDependencyCycle(names []string, dependsOn map[string][]string) (name string, ok bool) {
+	// Detect circular dependency by depth-first search.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var hasCycle func(name string) bool
+	hasCycle = func(name string) bool {
+		switch state[name] {
+		case visiting:
+			return true
+		case visited:
+			return false
+		}
+		state[name] = visiting
+		for _, dep := range dependsOn[name] {
+			if hasCycle(dep) {
+				return true
+			}
+		}
+		state[name] = visited
+		return false
+	}
+
+	for _, name := range names {
+		if hasCycle(name) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -308,7 +308,16 @@ func (cc *Controller) processNextReq(count uint32) bool {
 		return true
 	}
 
-	action := applyPolicies(jobInfo.Job, &req)
+	req = cc.checkTimeout(queue, jobInfo, req)
+
+	action, err := cc.delayAction(queue, jobInfo, req, applyPolicies(jobInfo.Job, &req))
+	if err != nil {
+		glog.Errorf("Failed to delay action of Job <%s/%s>: %v",
+			jobInfo.Job.Namespace, jobInfo.Job.Name, err)
+		queue.AddRateLimited(req)
+		return true
+	}
+
 	glog.V(3).Infof("Execute <%v> on Job <%s/%s> in <%s> by <%T>.",
 		action, req.Namespace, req.JobName, jobInfo.Job.Status.State.Phase, st)
 
@@ -325,8 +334,19 @@ func (cc *Controller) processNextReq(count uint32) bool {
 		return true
 	}
 
+	// Sync the restarting job again once its backoff ends.
+	if jobInfo, err := cc.cache.Get(key); err == nil {
+		if after := retryAfter(jobInfo.Job, time.Now()); after > 0 {
+			queue.AddAfter(apis.Request{
+				Namespace: req.Namespace,
+				JobName:   req.JobName,
+				Event:     vkbatchv1.OutOfSyncEvent,
+			}, after)
+		}
+	}
+
 	// If no error, forget it.
-	queue.Forget(req)
+	queue.Forget(obj)
 
 	return true
 }
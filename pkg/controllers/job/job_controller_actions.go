@@ -94,15 +94,17 @@ func (cc *Controller) killJob(jobInfo *apis.JobInfo, podRetainPhase state.PhaseM
 	job.Status = vkv1.JobStatus{
 		State: job.Status.State,
 
-		Pending:      pending,
-		Running:      running,
-		Succeeded:    succeeded,
-		Failed:       failed,
-		Terminating:  terminating,
-		Unknown:      unknown,
-		Version:      job.Status.Version,
-		MinAvailable: int32(job.Spec.MinAvailable),
-		RetryCount:   job.Status.RetryCount,
+		Pending:       pending,
+		Running:       running,
+		Succeeded:     succeeded,
+		Failed:        failed,
+		Terminating:   terminating,
+		Unknown:       unknown,
+		Version:       job.Status.Version,
+		MinAvailable:  int32(job.Spec.MinAvailable),
+		RetryCount:    job.Status.RetryCount,
+		NextRetryTime: job.Status.NextRetryTime,
+		TaskReplicas:  job.Status.TaskReplicas,
 	}
 
 	if updateStatus != nil {
@@ -215,6 +217,15 @@ func (cc *Controller) syncJob(jobInfo *apis.JobInfo, updateStatus state.UpdateSt
 	var podToDelete []*v1.Pod
 	var creationErrs []error
 	var deletionErrs []error
+	// scaled is set if replicas of a task are changed since the last sync.
+	var scaled bool
+	taskReplicas := map[string]int32{}
+
+	// Check dependencies before pods are picked out of jobInfo.Pods below.
+	dependsOnReady := map[string]bool{}
+	for _, ts := range job.Spec.Tasks {
+		dependsOnReady[ts.Name] = isDependsOnReady(job, ts.DependsOn, jobInfo.Pods)
+	}
 
 	for _, ts := range job.Spec.Tasks {
 		ts.Template.Name = ts.Name
@@ -225,10 +236,22 @@ func (cc *Controller) syncJob(jobInfo *apis.JobInfo, updateStatus state.UpdateSt
 		if !found {
 			pods = map[string]*v1.Pod{}
 		}
+		taskReplicas[name] = ts.Replicas
+		if replicas, found := job.Status.TaskReplicas[name]; found && replicas != ts.Replicas {
+			scaled = true
+		}
+
+		if !dependsOnReady[name] {
+			glog.V(3).Infof("Task <%s> of Job <%s/%s> is waiting for depended tasks %v",
+				name, job.Namespace, job.Name, ts.DependsOn.Name)
+		}
 
 		for i := 0; i < int(ts.Replicas); i++ {
 			podName := fmt.Sprintf(vkjobhelpers.PodNameFmt, job.Name, name, i)
 			if pod, found := pods[podName]; !found {
+				if !dependsOnReady[name] {
+					continue
+				}
 				newPod := createJobPod(job, tc, i)
 				if err := cc.pluginOnPodCreate(job, newPod); err != nil {
 					return err
@@ -251,6 +274,14 @@ func (cc *Controller) syncJob(jobInfo *apis.JobInfo, updateStatus state.UpdateSt
 		}
 	}
 
+	if scaled {
+		if err := cc.pluginOnJobUpdate(job); err != nil {
+			cc.recorder.Event(job, v1.EventTypeWarning, string(vkv1.PluginError),
+				fmt.Sprintf("Execute plugin when job scaled failed, err: %v", err))
+			return err
+		}
+	}
+
 	waitCreationGroup := sync.WaitGroup{}
 	waitCreationGroup.Add(len(podToCreate))
 	for _, pod := range podToCreate {
@@ -327,6 +358,9 @@ func (cc *Controller) syncJob(jobInfo *apis.JobInfo, updateStatus state.UpdateSt
 		MinAvailable:        int32(job.Spec.MinAvailable),
 		ControlledResources: job.Status.ControlledResources,
 		RetryCount:          job.Status.RetryCount,
+		Conditions:          job.Status.Conditions,
+		NextRetryTime:       job.Status.NextRetryTime,
+		TaskReplicas:        taskReplicas,
 	}
 
 	if updateStatus != nil {
@@ -527,6 +561,7 @@ func (cc *Controller) initJobStatus(job *vkv1.Job) (*vkv1.Job, error) {
 	}
 
 	job.Status.State.Phase = vkv1.Pending
+	job.Status.State.LastTransitionTime = metav1.Now()
 	job.Status.MinAvailable = int32(job.Spec.MinAvailable)
 	newJob, err := cc.vkClients.BatchV1alpha1().Jobs(job.Namespace).UpdateStatus(job)
 	if err != nil {
@@ -384,6 +384,89 @@ func TestSyncJobFunc(t *testing.T) {
 	}
 }
 
+func TestSyncJobScaled(t *testing.T) {
+	namespace := "test"
+
+	testcases := []struct {
+		Name         string
+		TaskReplicas map[string]int32
+		ExpectHosts  string
+	}{
+		{
+			Name:         "missing pod is recreated",
+			TaskReplicas: map[string]int32{"worker": 2},
+			ExpectHosts:  "job1-worker-0.job1",
+		},
+		{
+			Name:         "task is scaled up",
+			TaskReplicas: map[string]int32{"worker": 1},
+			ExpectHosts:  "job1-worker-0.job1\njob1-worker-1.job1",
+		},
+	}
+
+	for _, testcase := range testcases {
+		job := &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "job1",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.JobSpec{
+				Tasks: []v1alpha1.TaskSpec{
+					{Name: "worker", Replicas: 1},
+				},
+				Plugins: map[string][]string{"svc": {}},
+			},
+			Status: v1alpha1.JobStatus{
+				ControlledResources: map[string]string{},
+			},
+		}
+
+		fakeController := newFakeController()
+		if err := fakeController.pluginOnJobAdd(job); err != nil {
+			t.Fatalf("%s: expected no error while adding plugins, but got %v", testcase.Name, err)
+		}
+
+		job.Spec.Tasks[0].Replicas = 2
+		job.Status.TaskReplicas = testcase.TaskReplicas
+		if _, err := fakeController.vkClients.BatchV1alpha1().Jobs(namespace).Create(job); err != nil {
+			t.Fatalf("%s: expected no error while creating job, but got %v", testcase.Name, err)
+		}
+		if err := fakeController.cache.Add(job); err != nil {
+			t.Fatalf("%s: expected no error while adding job in cache, but got %v", testcase.Name, err)
+		}
+
+		jobInfo := &apis.JobInfo{
+			Namespace: namespace,
+			Name:      job.Name,
+			Job:       job,
+			Pods: map[string]map[string]*v1.Pod{
+				"worker": {
+					"job1-worker-0": buildPod(namespace, "job1-worker-0", v1.PodRunning, nil),
+				},
+			},
+		}
+		if err := fakeController.syncJob(jobInfo, nil); err != nil {
+			t.Fatalf("%s: expected no error while syncing job, but got %v", testcase.Name, err)
+		}
+
+		cm, err := fakeController.kubeClients.CoreV1().ConfigMaps(namespace).Get("job1-svc", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("%s: expected ConfigMap of svc plugin, but got error %v", testcase.Name, err)
+		}
+		if hosts := cm.Data["worker.host"]; hosts != testcase.ExpectHosts {
+			t.Errorf("%s: expected hosts %q, got %q", testcase.Name, testcase.ExpectHosts, hosts)
+		}
+
+		newJob, err := fakeController.vkClients.BatchV1alpha1().Jobs(namespace).Get(job.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("%s: expected no error while getting job, but got %v", testcase.Name, err)
+		}
+		if replicas := newJob.Status.TaskReplicas["worker"]; replicas != 2 {
+			t.Errorf("%s: expected replicas 2 of task worker recorded, got %d", testcase.Name, replicas)
+		}
+	}
+}
+
 func TestCreateJobIOIfNotExistFunc(t *testing.T) {
 	namespace := "test"
 
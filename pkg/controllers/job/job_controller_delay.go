@@ -0,0 +1,176 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	vkv1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+)
+
+// delayAction handles the timeout of the policy which triggered the action
+// of request. At first, the action is marked pending in the job conditions,
+// and the request is requeued after the timeout; when the delayed request
+// comes back, the action is taken only if the event persists. It returns the
+// action to execute now.
+func (cc *Controller) delayAction(queue workqueue.RateLimitingInterface, jobInfo *apis.JobInfo,
+	req apis.Request, action vkv1.Action) (vkv1.Action, error) {
+	if len(req.Action) != 0 {
+		return action, nil
+	}
+
+	policy := findPolicy(jobInfo.Job, &req)
+
+	if req.Delayed {
+		if err := cc.updateConditions(jobInfo, func(conditions []vkv1.JobCondition) []vkv1.JobCondition {
+			return removeActionPending(conditions, &req)
+		}); err != nil {
+			return "", err
+		}
+
+		if policy == nil || policy.Timeout == nil {
+			return action, nil
+		}
+		if !eventPersists(jobInfo, &req) {
+			glog.V(3).Infof("Event %s of Job <%s/%s> recovered in %v, skip action %s.",
+				req.Event, req.Namespace, req.JobName, policy.Timeout.Duration, action)
+			return vkv1.SyncJobAction, nil
+		}
+		return action, nil
+	}
+
+	if policy == nil || policy.Timeout == nil {
+		return action, nil
+	}
+
+	delayed := req
+	delayed.Delayed = true
+
+	// The action may be pending already, e.g. the job timed out again, or
+	// the controller restarted before the deadline.
+	for _, c := range jobInfo.Job.Status.Conditions {
+		if !isActionPending(c, &req) || c.Deadline == nil {
+			continue
+		}
+		if remaining := c.Deadline.Sub(time.Now()); remaining > 0 {
+			queue.AddAfter(delayed, remaining)
+			return vkv1.SyncJobAction, nil
+		}
+		return cc.delayAction(queue, jobInfo, delayed, action)
+	}
+
+	deadline := metav1.NewTime(time.Now().Add(policy.Timeout.Duration))
+	if err := cc.updateConditions(jobInfo, func(conditions []vkv1.JobCondition) []vkv1.JobCondition {
+		return append(removeActionPending(conditions, &req), vkv1.JobCondition{
+			Type:               vkv1.JobActionPending,
+			Action:             action,
+			Event:              req.Event,
+			TaskName:           req.TaskName,
+			PodName:            requestPodName(&req),
+			Deadline:           &deadline,
+			LastTransitionTime: metav1.Now(),
+		})
+	}); err != nil {
+		return "", err
+	}
+
+	cc.recordJobEvent(req.Namespace, req.JobName, vkv1.ExecuteAction, fmt.Sprintf(
+		"Action %s for event %s is delayed until %v", action, req.Event, deadline))
+
+	queue.AddAfter(delayed, policy.Timeout.Duration)
+
+	return vkv1.SyncJobAction, nil
+}
+
+// eventPersists returns whether the pod of request is still failed or gone;
+// events without pod always persist.
+func eventPersists(jobInfo *apis.JobInfo, req *apis.Request) bool {
+	if req.Pod == nil {
+		return true
+	}
+
+	pod, found := jobInfo.Pods[req.TaskName][req.Pod.Name]
+	if !found {
+		return true
+	}
+
+	return pod.Status.Phase == v1.PodFailed
+}
+
+func requestPodName(req *apis.Request) string {
+	if req.Pod == nil {
+		return ""
+	}
+	return req.Pod.Name
+}
+
+// removeActionPending removes the pending action triggered by the same
+// event, task and pod as request.
+func removeActionPending(conditions []vkv1.JobCondition, req *apis.Request) []vkv1.JobCondition {
+	var result []vkv1.JobCondition
+	for _, c := range conditions {
+		if isActionPending(c, req) {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// isActionPending returns whether the condition is the pending action
+// triggered by the same event, task and pod as request.
+func isActionPending(c vkv1.JobCondition, req *apis.Request) bool {
+	return c.Type == vkv1.JobActionPending && c.Event == req.Event &&
+		c.TaskName == req.TaskName && c.PodName == requestPodName(req)
+}
+
+// updateConditions updates the conditions of job if changed, and replaces the
+// job in cache and jobInfo by the updated one.
+func (cc *Controller) updateConditions(jobInfo *apis.JobInfo,
+	update func([]vkv1.JobCondition) []vkv1.JobCondition) error {
+	job := jobInfo.Job.DeepCopy()
+	conditions := update(job.Status.Conditions)
+	if (len(conditions) == 0 && len(job.Status.Conditions) == 0) ||
+		reflect.DeepEqual(conditions, job.Status.Conditions) {
+		return nil
+	}
+	job.Status.Conditions = conditions
+
+	newJob, err := cc.vkClients.BatchV1alpha1().Jobs(job.Namespace).UpdateStatus(job)
+	if err != nil {
+		glog.Errorf("Failed to update conditions of Job %v/%v: %v",
+			job.Namespace, job.Name, err)
+		return err
+	}
+	if err := cc.cache.Update(newJob); err != nil {
+		glog.Errorf("Failed to update Job %v/%v in cache: %v",
+			newJob.Namespace, newJob.Name, err)
+		return err
+	}
+	jobInfo.Job = newJob
+
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	vkv1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	jobcache "volcano.sh/volcano/pkg/controllers/cache"
+)
+
+func TestDelayAction(t *testing.T) {
+	namespace := "test"
+
+	testcases := []struct {
+		Name string
+		// RecoveredPhase is the phase of the failed pod when the delayed
+		// request comes back, empty if it's still failed.
+		RecoveredPhase v1.PodPhase
+		ExpectedAction vkv1.Action
+	}{
+		{
+			Name:           "action is taken if the pod is still failed",
+			ExpectedAction: vkv1.RestartJobAction,
+		},
+		{
+			Name:           "action is skipped if the pod recovered",
+			RecoveredPhase: v1.PodRunning,
+			ExpectedAction: vkv1.SyncJobAction,
+		},
+	}
+
+	for i, testcase := range testcases {
+		fakeController := newFakeController()
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+		job := &vkv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "job1",
+				Namespace: namespace,
+			},
+			Spec: vkv1.JobSpec{
+				Tasks: []vkv1.TaskSpec{
+					{
+						Name:     "worker",
+						Replicas: 2,
+					},
+				},
+				Policies: []vkv1.LifecyclePolicy{
+					{
+						Event:   vkv1.PodFailedEvent,
+						Action:  vkv1.RestartJobAction,
+						Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			},
+		}
+		if _, err := fakeController.vkClients.BatchV1alpha1().Jobs(namespace).Create(job); err != nil {
+			t.Fatalf("Case %d: failed to create job: %v", i, err)
+		}
+		if err := fakeController.cache.Add(job); err != nil {
+			t.Fatalf("Case %d: failed to add job into cache: %v", i, err)
+		}
+
+		pod := buildPod(namespace, "job1-worker-0", v1.PodFailed, nil)
+		pod.Annotations = map[string]string{
+			vkv1.TaskSpecKey: "worker",
+			vkv1.JobNameKey:  "job1",
+			vkv1.JobVersion:  "0",
+		}
+		if err := fakeController.cache.AddPod(pod); err != nil {
+			t.Fatalf("Case %d: failed to add pod into cache: %v", i, err)
+		}
+
+		req := apis.Request{
+			Namespace: namespace,
+			JobName:   "job1",
+			TaskName:  "worker",
+			Event:     vkv1.PodFailedEvent,
+			Pod:       pod,
+		}
+
+		jobInfo, _ := fakeController.cache.Get(jobcache.JobKeyByReq(&req))
+		action, err := fakeController.delayAction(queue, jobInfo, req, applyPolicies(jobInfo.Job, &req))
+		if err != nil {
+			t.Fatalf("Case %d (%s): failed to delay action: %v", i, testcase.Name, err)
+		}
+		if action != vkv1.SyncJobAction {
+			t.Errorf("Case %d (%s): expected action %s before timeout, got %s",
+				i, testcase.Name, vkv1.SyncJobAction, action)
+		}
+
+		jobInfo, _ = fakeController.cache.Get(jobcache.JobKeyByReq(&req))
+		conditions := jobInfo.Job.Status.Conditions
+		if len(conditions) != 1 || conditions[0].Type != vkv1.JobActionPending ||
+			conditions[0].Action != vkv1.RestartJobAction || conditions[0].PodName != pod.Name ||
+			conditions[0].Deadline == nil {
+			t.Errorf("Case %d (%s): expected pending action in conditions, got %v",
+				i, testcase.Name, conditions)
+		}
+
+		if len(testcase.RecoveredPhase) != 0 {
+			recovered := buildPod(namespace, pod.Name, testcase.RecoveredPhase, nil)
+			recovered.Annotations = pod.Annotations
+			if err := fakeController.cache.UpdatePod(recovered); err != nil {
+				t.Fatalf("Case %d: failed to update pod in cache: %v", i, err)
+			}
+		}
+
+		delayed := req
+		delayed.Delayed = true
+		jobInfo, _ = fakeController.cache.Get(jobcache.JobKeyByReq(&req))
+		action, err = fakeController.delayAction(queue, jobInfo, delayed, applyPolicies(jobInfo.Job, &delayed))
+		if err != nil {
+			t.Fatalf("Case %d (%s): failed to handle delayed request: %v", i, testcase.Name, err)
+		}
+		if action != testcase.ExpectedAction {
+			t.Errorf("Case %d (%s): expected action %s after timeout, got %s",
+				i, testcase.Name, testcase.ExpectedAction, action)
+		}
+		if len(jobInfo.Job.Status.Conditions) != 0 {
+			t.Errorf("Case %d (%s): expected pending action to be removed, got %v",
+				i, testcase.Name, jobInfo.Job.Status.Conditions)
+		}
+	}
+}
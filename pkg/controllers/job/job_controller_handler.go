@@ -229,14 +229,12 @@ func (cc *Controller) updatePod(oldObj, newObj interface{}) {
 
 	event := vkbatchv1.OutOfSyncEvent
 	var exitCode int32
+	var failedPod *v1.Pod
 	if oldPod.Status.Phase != v1.PodFailed &&
 		newPod.Status.Phase == v1.PodFailed {
 		event = vkbatchv1.PodFailedEvent
-		// TODO: currently only one container pod is supported by volcano
-		// Once multi containers pod is supported, update accordingly.
-		if len(newPod.Status.ContainerStatuses) > 0 && newPod.Status.ContainerStatuses[0].State.Terminated != nil {
-			exitCode = newPod.Status.ContainerStatuses[0].State.Terminated.ExitCode
-		}
+		exitCode = failedExitCode(newPod)
+		failedPod = newPod
 	}
 
 	if oldPod.Status.Phase != v1.PodSucceeded &&
@@ -254,6 +252,7 @@ func (cc *Controller) updatePod(oldObj, newObj interface{}) {
 		Event:      event,
 		ExitCode:   exitCode,
 		JobVersion: int32(dVersion),
+		Pod:        failedPod,
 	}
 
 	key := vkjobhelpers.GetJobKeyByReq(&req)
@@ -310,6 +309,23 @@ func (cc *Controller) deletePod(obj interface{}) {
 		JobVersion: int32(dVersion),
 	}
 
+	// The termination of a finished pod was handled when it failed, e.g.
+	// it's deleted when the job is aborted or restarted, so only the pods
+	// evicted before they finished carry their termination.
+	if pod.Status.Phase != v1.PodFailed && pod.Status.Phase != v1.PodSucceeded {
+		req.ExitCode = failedExitCode(pod)
+		req.Pod = pod
+	}
+
+	// Pods deleted by scaling down the task are not evicted, so policies
+	// of the job should not be triggered.
+	if jobInfo, err := cc.cache.Get(vkcache.JobKeyByName(pod.Namespace, jobName)); err == nil &&
+		isScaledDownPod(jobInfo.Job, pod) {
+		req.Event = vkbatchv1.OutOfSyncEvent
+		req.ExitCode = 0
+		req.Pod = nil
+	}
+
 	if err := cc.cache.DeletePod(pod); err != nil {
 		glog.Errorf("Failed to delete Pod <%s/%s>: %v in cache",
 			pod.Namespace, pod.Name, err)
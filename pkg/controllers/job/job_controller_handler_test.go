@@ -32,6 +32,7 @@ import (
 	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
 	kubebatchclient "volcano.sh/volcano/pkg/client/clientset/versioned"
 	vkclientset "volcano.sh/volcano/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/controllers/apis"
 	//"volcano.sh/volcano/pkg/controllers/job"
 )
 
@@ -488,6 +489,75 @@ func TestDeletePodFunc(t *testing.T) {
 	}
 }
 
+func TestDeletePodTermination(t *testing.T) {
+	namespace := "test"
+
+	testcases := []struct {
+		Name             string
+		Phase            v1.PodPhase
+		ExpectedExitCode int32
+		ExpectedPod      bool
+	}{
+		{
+			Name:             "running pod is evicted with its termination",
+			Phase:            v1.PodRunning,
+			ExpectedExitCode: 137,
+			ExpectedPod:      true,
+		},
+		{
+			Name:             "failed pod is deleted without its termination",
+			Phase:            v1.PodFailed,
+			ExpectedExitCode: 0,
+			ExpectedPod:      false,
+		},
+	}
+
+	for i, testcase := range testcases {
+		controller := newController()
+		controller.addJob(&vkbatchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "job1",
+				Namespace: namespace,
+			},
+		})
+
+		pod := buildPod(namespace, "pod1", testcase.Phase, nil)
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{
+			{
+				Name: "nginx",
+				State: v1.ContainerState{
+					Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+				},
+			},
+		}
+		addPodAnnotation(pod, map[string]string{
+			vkbatchv1.JobNameKey:  "job1",
+			vkbatchv1.JobVersion:  "0",
+			vkbatchv1.TaskSpecKey: "task1",
+		})
+		controller.addPod(pod)
+		controller.deletePod(pod)
+
+		queue := controller.getWorkerQueue(fmt.Sprintf("%s/%s", namespace, "job1"))
+		var req apis.Request
+		for queue.Len() != 0 {
+			item, _ := queue.Get()
+			req = item.(apis.Request)
+			queue.Done(item)
+		}
+
+		if req.Event != vkbatchv1.PodEvictedEvent {
+			t.Errorf("case %d (%s): expected event %s, got %s", i, testcase.Name, vkbatchv1.PodEvictedEvent, req.Event)
+		}
+		if req.ExitCode != testcase.ExpectedExitCode {
+			t.Errorf("case %d (%s): expected exit code %d, got %d", i, testcase.Name, testcase.ExpectedExitCode, req.ExitCode)
+		}
+		if (req.Pod != nil) != testcase.ExpectedPod {
+			t.Errorf("case %d (%s): expected pod in request %t, got %v", i, testcase.Name, testcase.ExpectedPod, req.Pod)
+		}
+	}
+}
+
 func TestUpdatePodGroupFunc(t *testing.T) {
 
 	namespace := "test"
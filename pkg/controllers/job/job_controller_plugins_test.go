@@ -285,3 +285,48 @@ func TestPluginOnJobDelete(t *testing.T) {
 		}
 	}
 }
+
+func TestPluginOnJobUpdate(t *testing.T) {
+	namespace := "test"
+
+	job := &vkv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job1",
+			Namespace: namespace,
+		},
+		Spec: vkv1.JobSpec{
+			Tasks: []vkv1.TaskSpec{
+				{Name: "worker", Replicas: 1},
+			},
+			Plugins: map[string][]string{"svc": {}, "ssh": {}, "env": {}},
+		},
+		Status: vkv1.JobStatus{
+			ControlledResources: map[string]string{},
+		},
+	}
+
+	fakeController := newFakeController()
+	if err := fakeController.pluginOnJobAdd(job); err != nil {
+		t.Fatalf("expected: nil, got %v", err)
+	}
+
+	job.Spec.Tasks[0].Replicas = 2
+	if err := fakeController.pluginOnJobUpdate(job); err != nil {
+		t.Fatalf("expected: nil, got %v", err)
+	}
+
+	cm, err := fakeController.kubeClients.CoreV1().ConfigMaps(namespace).Get("job1-svc", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected: ConfigMap to be updated, but got error %v", err)
+	}
+
+	expected := "job1-worker-0.job1\njob1-worker-1.job1"
+	if hosts := cm.Data["worker.host"]; hosts != expected {
+		t.Errorf("expected: hosts %q, got %q", expected, hosts)
+	}
+
+	job.Spec.Plugins = map[string][]string{"new": {}}
+	if err := fakeController.pluginOnJobUpdate(job); err == nil {
+		t.Errorf("expected: error for unknown plugin, got nil")
+	}
+}
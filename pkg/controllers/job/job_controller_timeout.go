@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/client-go/util/workqueue"
+
+	vkv1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+)
+
+// checkTimeout returns the request to handle for job: a JobTimeout request
+// if the job exceeded its activeDeadlineSeconds or pendingTimeout, otherwise
+// req itself. In the latter case, a JobTimeout request is queued to check the
+// job again when it's going to time out.
+func (cc *Controller) checkTimeout(queue workqueue.RateLimitingInterface, jobInfo *apis.JobInfo,
+	req apis.Request) apis.Request {
+	if len(req.Action) != 0 {
+		return req
+	}
+
+	job := jobInfo.Job
+	timeoutReq := apis.Request{
+		Namespace:  job.Namespace,
+		JobName:    job.Name,
+		Event:      vkv1.JobTimeoutEvent,
+		JobVersion: job.Status.Version,
+	}
+
+	reason, after := jobTimeout(job, time.Now())
+	if len(reason) == 0 {
+		if after > 0 {
+			queue.AddAfter(timeoutReq, after)
+		}
+		// The job was restarted or finished before the timeout.
+		if req.Event == vkv1.JobTimeoutEvent {
+			req.Event = vkv1.OutOfSyncEvent
+		}
+		return req
+	}
+
+	if req.Event == vkv1.JobTimeoutEvent {
+		return req
+	}
+
+	glog.V(3).Infof("Job <%s/%s> timed out: %s.", job.Namespace, job.Name, reason)
+	cc.recordJobEvent(job.Namespace, job.Name, vkv1.ExecuteAction, fmt.Sprintf(
+		"Job timed out: %s", reason))
+
+	return timeoutReq
+}
+
+// jobTimeout returns the reason if job exceeded its activeDeadlineSeconds or
+// pendingTimeout at now; otherwise, the duration until it times out, or 0 if
+// it never does in current phase.
+func jobTimeout(job *vkv1.Job, now time.Time) (string, time.Duration) {
+	var deadline time.Time
+	var reason string
+
+	switch job.Status.State.Phase {
+	case "", vkv1.Pending, vkv1.Inqueue, vkv1.Running, vkv1.Restarting:
+	default:
+		return "", 0
+	}
+
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		deadline = job.CreationTimestamp.Add(time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second)
+		reason = fmt.Sprintf("active for more than %d seconds", *job.Spec.ActiveDeadlineSeconds)
+	}
+
+	phase := job.Status.State.Phase
+	if job.Spec.PendingTimeout != nil && (phase == "" || phase == vkv1.Pending) {
+		since := job.Status.State.LastTransitionTime.Time
+		if since.IsZero() {
+			since = job.CreationTimestamp.Time
+		}
+		if pendingDeadline := since.Add(job.Spec.PendingTimeout.Duration); deadline.IsZero() || pendingDeadline.Before(deadline) {
+			deadline = pendingDeadline
+			reason = fmt.Sprintf("pending for more than %v", job.Spec.PendingTimeout.Duration)
+		}
+	}
+
+	if deadline.IsZero() {
+		return "", 0
+	}
+	if !now.Before(deadline) {
+		return reason, 0
+	}
+
+	return "", deadline.Sub(now)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	vkv1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+)
+
+func TestJobTimeout(t *testing.T) {
+	now := time.Now()
+	deadline := int64(3600)
+
+	buildJob := func(phase vkv1.JobPhase, created, transited time.Duration) *vkv1.Job {
+		return &vkv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "job1",
+				Namespace:         "test",
+				CreationTimestamp: metav1.NewTime(now.Add(-created)),
+			},
+			Spec: vkv1.JobSpec{
+				ActiveDeadlineSeconds: &deadline,
+				PendingTimeout:        &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			Status: vkv1.JobStatus{
+				State: vkv1.JobState{
+					Phase:              phase,
+					LastTransitionTime: metav1.NewTime(now.Add(-transited)),
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		Name          string
+		Job           *vkv1.Job
+		ExpectTimeout bool
+		ExpectAfter   time.Duration
+	}{
+		{
+			Name:        "pending job before pending timeout",
+			Job:         buildJob(vkv1.Pending, 2*time.Minute, 2*time.Minute),
+			ExpectAfter: 8 * time.Minute,
+		},
+		{
+			Name:          "pending job after pending timeout",
+			Job:           buildJob(vkv1.Pending, 20*time.Minute, 15*time.Minute),
+			ExpectTimeout: true,
+		},
+		{
+			Name:        "running job is not checked for pending timeout",
+			Job:         buildJob(vkv1.Running, 20*time.Minute, 15*time.Minute),
+			ExpectAfter: 40 * time.Minute,
+		},
+		{
+			Name:          "running job after active deadline",
+			Job:           buildJob(vkv1.Running, 2*time.Hour, time.Hour),
+			ExpectTimeout: true,
+		},
+		{
+			Name: "finished job never times out",
+			Job:  buildJob(vkv1.Completed, 2*time.Hour, time.Hour),
+		},
+	}
+
+	for i, testcase := range testcases {
+		reason, after := jobTimeout(testcase.Job, now)
+		if testcase.ExpectTimeout != (len(reason) != 0) {
+			t.Errorf("Case %d (%s): expected timeout %t, got reason %q",
+				i, testcase.Name, testcase.ExpectTimeout, reason)
+		}
+		if after != testcase.ExpectAfter {
+			t.Errorf("Case %d (%s): expected to time out after %v, got %v",
+				i, testcase.Name, testcase.ExpectAfter, after)
+		}
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	fakeController := newFakeController()
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	deadline := int64(60)
+
+	job := &vkv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job1",
+			Namespace:         "test",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Spec: vkv1.JobSpec{
+			ActiveDeadlineSeconds: &deadline,
+		},
+		Status: vkv1.JobStatus{
+			State: vkv1.JobState{Phase: vkv1.Running},
+		},
+	}
+	jobInfo := &apis.JobInfo{Job: job}
+
+	req := apis.Request{
+		Namespace: "test",
+		JobName:   "job1",
+		Event:     vkv1.OutOfSyncEvent,
+	}
+	req = fakeController.checkTimeout(queue, jobInfo, req)
+	if req.Event != vkv1.JobTimeoutEvent {
+		t.Errorf("expected event %s, got %s", vkv1.JobTimeoutEvent, req.Event)
+	}
+	if action := applyPolicies(job, &req); action != vkv1.TerminateJobAction {
+		t.Errorf("expected default action %s, got %s", vkv1.TerminateJobAction, action)
+	}
+
+	job.Spec.Policies = []vkv1.LifecyclePolicy{
+		{
+			Event:  vkv1.JobTimeoutEvent,
+			Action: vkv1.AbortJobAction,
+		},
+	}
+	if action := applyPolicies(job, &req); action != vkv1.AbortJobAction {
+		t.Errorf("expected action %s by policy, got %s", vkv1.AbortJobAction, action)
+	}
+
+	// The timeout is outdated once the job finished.
+	job.Status.State.Phase = vkv1.Terminated
+	req = fakeController.checkTimeout(queue, jobInfo, req)
+	if req.Event != vkv1.OutOfSyncEvent {
+		t.Errorf("expected event %s, got %s", vkv1.OutOfSyncEvent, req.Event)
+	}
+}
@@ -18,6 +18,8 @@ package job
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/golang/glog"
 
@@ -123,19 +125,56 @@ func createJobPod(job *vkv1.Job, template *v1.PodTemplateSpec, ix int) *v1.Pod {
 	return pod
 }
 
+// isScaledDownPod returns true if the index of the pod is beyond the replicas
+// of its task, i.e. the pod is removed by scaling down the task.
+func isScaledDownPod(job *vkv1.Job, pod *v1.Pod) bool {
+	if job == nil {
+		return false
+	}
+
+	index, err := strconv.Atoi(vkjobhelpers.GetTaskIndex(pod))
+	if err != nil {
+		return false
+	}
+
+	taskName := pod.Annotations[vkv1.TaskSpecKey]
+	for _, task := range job.Spec.Tasks {
+		if task.Name == taskName {
+			return index >= int(task.Replicas)
+		}
+	}
+
+	return false
+}
+
 func applyPolicies(job *vkv1.Job, req *apis.Request) vkv1.Action {
 	if len(req.Action) != 0 {
 		return req.Action
 	}
 
+	if policy := findPolicy(job, req); policy != nil {
+		return policy.Action
+	}
+
+	// The job is terminated if it times out without policy.
+	if req.Event == vkv1.JobTimeoutEvent && req.JobVersion >= job.Status.Version {
+		return vkv1.TerminateJobAction
+	}
+
+	return vkv1.SyncJobAction
+}
+
+// findPolicy returns the policy triggered by the event of request, task level
+// policies go first; nil if none, or the request is outdated.
+func findPolicy(job *vkv1.Job, req *apis.Request) *vkv1.LifecyclePolicy {
 	if req.Event == vkv1.OutOfSyncEvent {
-		return vkv1.SyncJobAction
+		return nil
 	}
 
 	// For all the requests triggered from discarded job resources will perform sync action instead
 	if req.JobVersion < job.Status.Version {
 		glog.Infof("Request %s is outdated, will perform sync instead.", req)
-		return vkv1.SyncJobAction
+		return nil
 	}
 
 	// Overwrite Job level policies
@@ -143,18 +182,17 @@ func applyPolicies(job *vkv1.Job, req *apis.Request) vkv1.Action {
 		// Parse task level policies
 		for _, task := range job.Spec.Tasks {
 			if task.Name == req.TaskName {
-				for _, policy := range task.Policies {
+				for i, policy := range task.Policies {
 					policyEvents := getEventlist(policy)
 
 					if len(policyEvents) > 0 && len(req.Event) > 0 {
 						if checkEventExist(policyEvents, req.Event) || checkEventExist(policyEvents, vkv1.AnyEvent) {
-							return policy.Action
+							return &task.Policies[i]
 						}
 					}
 
-					// 0 is not an error code, is prevented in validation admission controller
-					if policy.ExitCode != nil && *policy.ExitCode == req.ExitCode {
-						return policy.Action
+					if matchTermination(policy, req) {
+						return &task.Policies[i]
 					}
 				}
 				break
@@ -163,22 +201,107 @@ func applyPolicies(job *vkv1.Job, req *apis.Request) vkv1.Action {
 	}
 
 	// Parse Job level policies
-	for _, policy := range job.Spec.Policies {
+	for i, policy := range job.Spec.Policies {
+		if len(policy.TaskName) != 0 && policy.TaskName != req.TaskName {
+			continue
+		}
+
 		policyEvents := getEventlist(policy)
 
 		if len(policyEvents) > 0 && len(req.Event) > 0 {
 			if checkEventExist(policyEvents, req.Event) || checkEventExist(policyEvents, vkv1.AnyEvent) {
-				return policy.Action
+				return &job.Spec.Policies[i]
 			}
 		}
 
-		// 0 is not an error code, is prevented in validation admission controller
-		if policy.ExitCode != nil && *policy.ExitCode == req.ExitCode {
-			return policy.Action
+		if matchTermination(policy, req) {
+			return &job.Spec.Policies[i]
 		}
 	}
 
-	return vkv1.SyncJobAction
+	return nil
+}
+
+// matchTermination returns whether the exit codes or termination reasons of
+// the policy match the pod which failed or was evicted in the request.
+func matchTermination(policy vkv1.LifecyclePolicy, req *apis.Request) bool {
+	if policy.ExitCode == nil && len(policy.ExitCodes) == 0 && len(policy.Reasons) == 0 {
+		return false
+	}
+
+	var exitCodes []int32
+	var reasons []string
+	if req.Pod == nil {
+		if len(policy.ContainerName) == 0 {
+			exitCodes = append(exitCodes, req.ExitCode)
+		}
+	} else {
+		if len(req.Pod.Status.Reason) != 0 {
+			reasons = append(reasons, req.Pod.Status.Reason)
+		}
+		for _, status := range req.Pod.Status.ContainerStatuses {
+			if len(policy.ContainerName) != 0 && status.Name != policy.ContainerName {
+				continue
+			}
+			if terminated := status.State.Terminated; terminated != nil {
+				exitCodes = append(exitCodes, terminated.ExitCode)
+				if len(terminated.Reason) != 0 {
+					reasons = append(reasons, terminated.Reason)
+				}
+			}
+		}
+	}
+
+	for _, exitCode := range exitCodes {
+		if matchExitCode(policy, exitCode) {
+			return true
+		}
+	}
+
+	for _, reason := range reasons {
+		for _, r := range policy.Reasons {
+			if r == reason {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchExitCode(policy vkv1.LifecyclePolicy, exitCode int32) bool {
+	// 0 is not an error code, is prevented in validation admission controller
+	if exitCode == 0 {
+		return false
+	}
+
+	if policy.ExitCode != nil && *policy.ExitCode == exitCode {
+		return true
+	}
+
+	for _, expr := range policy.ExitCodes {
+		matched, err := vkjobhelpers.MatchExitCode(expr, exitCode)
+		if err != nil {
+			glog.Warningf("Failed to match exit code %d: %v", exitCode, err)
+			continue
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// failedExitCode returns the exit code of the first container of pod which
+// terminated with a non-zero exit code, or 0 if none.
+func failedExitCode(pod *v1.Pod) int32 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return terminated.ExitCode
+		}
+	}
+	return 0
 }
 
 func getEventlist(policy v1alpha1.LifecyclePolicy) []v1alpha1.Event {
@@ -219,6 +342,42 @@ func addResourceList(list, req, limit v1.ResourceList) {
 	}
 }
 
+// isDependsOnReady checks whether all pods of the tasks which the task depends on
+// have reached the phase required by dependsOn.
+func isDependsOnReady(job *vkv1.Job, dependsOn *vkv1.DependsOn, pods map[string]map[string]*v1.Pod) bool {
+	if dependsOn == nil {
+		return true
+	}
+
+	replicas := map[string]int32{}
+	for _, ts := range job.Spec.Tasks {
+		replicas[ts.Name] = ts.Replicas
+	}
+
+	for _, name := range dependsOn.Name {
+		var ready int32
+		for _, pod := range pods[name] {
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			switch pod.Status.Phase {
+			case v1.PodSucceeded:
+				ready++
+			case v1.PodRunning:
+				if dependsOn.Phase != v1.PodSucceeded {
+					ready++
+				}
+			}
+		}
+
+		if ready < replicas[name] {
+			return false
+		}
+	}
+
+	return true
+}
+
 //TaskPriority structure
 type TaskPriority struct {
 	priority int32
@@ -236,3 +395,17 @@ func (p TasksPriority) Less(i, j int) bool {
 }
 
 func (p TasksPriority) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+// retryAfter returns the duration until the pods of the restarting job are
+// recreated; it's zero if the job is not waiting for its restart backoff.
+func retryAfter(job *vkv1.Job, now time.Time) time.Duration {
+	if job.Status.State.Phase != vkv1.Restarting || job.Status.NextRetryTime == nil {
+		return 0
+	}
+
+	if after := job.Status.NextRetryTime.Sub(now); after > 0 {
+		return after
+	}
+
+	return 0
+}
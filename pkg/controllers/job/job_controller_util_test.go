@@ -603,6 +603,241 @@ func TestApplyPolicies(t *testing.T) {
 			Request:   &apis.Request{},
 			ReturnVal: v1alpha1.SyncJobAction,
 		},
+		{
+			Name: "Test Apply policies with job level policy scoped to the task",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job1",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					SchedulerName: "volcano",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "master",
+							Replicas: 1,
+						},
+						{
+							Name:     "worker",
+							Replicas: 6,
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Action:   v1alpha1.CompleteJobAction,
+							Event:    v1alpha1.TaskCompletedEvent,
+							TaskName: "master",
+						},
+					},
+				},
+				Status: v1alpha1.JobStatus{
+					Version: 1,
+				},
+			},
+			Request: &apis.Request{
+				TaskName:   "master",
+				Event:      v1alpha1.TaskCompletedEvent,
+				JobVersion: 1,
+			},
+			ReturnVal: v1alpha1.CompleteJobAction,
+		},
+		{
+			Name: "Test Apply policies with job level policy scoped to another task",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job1",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					SchedulerName: "volcano",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "master",
+							Replicas: 1,
+						},
+						{
+							Name:     "worker",
+							Replicas: 6,
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Action:   v1alpha1.CompleteJobAction,
+							Event:    v1alpha1.TaskCompletedEvent,
+							TaskName: "master",
+						},
+					},
+				},
+				Status: v1alpha1.JobStatus{
+					Version: 1,
+				},
+			},
+			Request: &apis.Request{
+				TaskName:   "worker",
+				Event:      v1alpha1.TaskCompletedEvent,
+				JobVersion: 1,
+			},
+			ReturnVal: v1alpha1.SyncJobAction,
+		},
+		{
+			Name: "Test Apply policies with termination reason of container",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job1",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					SchedulerName: "volcano",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task1",
+							Replicas: 6,
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Action:  v1alpha1.RestartJobAction,
+							Reasons: []string{"OOMKilled", "Evicted"},
+						},
+						{
+							Action:        v1alpha1.AbortJobAction,
+							ExitCodes:     []string{"3", "10-20"},
+							ContainerName: "main",
+						},
+					},
+				},
+			},
+			Request: &apis.Request{
+				TaskName: "task1",
+				Event:    v1alpha1.PodFailedEvent,
+				Pod: &v1.Pod{
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name: "sidecar",
+								State: v1.ContainerState{
+									Terminated: &v1.ContainerStateTerminated{ExitCode: 3},
+								},
+							},
+							{
+								Name: "main",
+								State: v1.ContainerState{
+									Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+								},
+							},
+						},
+					},
+				},
+			},
+			ReturnVal: v1alpha1.RestartJobAction,
+		},
+		{
+			Name: "Test Apply policies with exit code range of selected container",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job1",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					SchedulerName: "volcano",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task1",
+							Replicas: 6,
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Action:  v1alpha1.RestartJobAction,
+							Reasons: []string{"OOMKilled", "Evicted"},
+						},
+						{
+							Action:        v1alpha1.AbortJobAction,
+							ExitCodes:     []string{"3", "10-20"},
+							ContainerName: "main",
+						},
+					},
+				},
+			},
+			Request: &apis.Request{
+				TaskName: "task1",
+				Event:    v1alpha1.PodFailedEvent,
+				Pod: &v1.Pod{
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name: "sidecar",
+								State: v1.ContainerState{
+									Terminated: &v1.ContainerStateTerminated{ExitCode: 3},
+								},
+							},
+							{
+								Name: "main",
+								State: v1.ContainerState{
+									Terminated: &v1.ContainerStateTerminated{ExitCode: 15, Reason: "Error"},
+								},
+							},
+						},
+					},
+				},
+			},
+			ReturnVal: v1alpha1.AbortJobAction,
+		},
+		{
+			Name: "Test Apply policies with exit code of other container",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job1",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					SchedulerName: "volcano",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task1",
+							Replicas: 6,
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Action:  v1alpha1.RestartJobAction,
+							Reasons: []string{"OOMKilled", "Evicted"},
+						},
+						{
+							Action:        v1alpha1.AbortJobAction,
+							ExitCodes:     []string{"3", "10-20"},
+							ContainerName: "main",
+						},
+					},
+				},
+			},
+			Request: &apis.Request{
+				TaskName: "task1",
+				Event:    v1alpha1.PodFailedEvent,
+				Pod: &v1.Pod{
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name: "sidecar",
+								State: v1.ContainerState{
+									Terminated: &v1.ContainerStateTerminated{ExitCode: 3},
+								},
+							},
+							{
+								Name: "main",
+								State: v1.ContainerState{
+									Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+								},
+							},
+						},
+					},
+				},
+			},
+			ReturnVal: v1alpha1.SyncJobAction,
+		},
 	}
 
 	for i, testcase := range testcases {
@@ -615,6 +850,80 @@ func TestApplyPolicies(t *testing.T) {
 	}
 }
 
+func TestIsDependsOnReady(t *testing.T) {
+	job := &v1alpha1.Job{
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{
+				{Name: "ps", Replicas: 2},
+				{Name: "worker", Replicas: 2},
+			},
+		},
+	}
+
+	podsOf := func(phases ...v1.PodPhase) map[string]map[string]*v1.Pod {
+		pods := map[string]*v1.Pod{}
+		for i, phase := range phases {
+			name := MakePodName("job", "ps", i)
+			pods[name] = &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status:     v1.PodStatus{Phase: phase},
+			}
+		}
+		return map[string]map[string]*v1.Pod{"ps": pods}
+	}
+
+	testcases := []struct {
+		Name      string
+		DependsOn *v1alpha1.DependsOn
+		Pods      map[string]map[string]*v1.Pod
+		ReturnVal bool
+	}{
+		{
+			Name:      "no dependency",
+			DependsOn: nil,
+			Pods:      podsOf(),
+			ReturnVal: true,
+		},
+		{
+			Name:      "depended pods not created",
+			DependsOn: &v1alpha1.DependsOn{Name: []string{"ps"}},
+			Pods:      podsOf(),
+			ReturnVal: false,
+		},
+		{
+			Name:      "depended pods partially running",
+			DependsOn: &v1alpha1.DependsOn{Name: []string{"ps"}},
+			Pods:      podsOf(v1.PodRunning, v1.PodPending),
+			ReturnVal: false,
+		},
+		{
+			Name:      "depended pods running or succeeded",
+			DependsOn: &v1alpha1.DependsOn{Name: []string{"ps"}},
+			Pods:      podsOf(v1.PodRunning, v1.PodSucceeded),
+			ReturnVal: true,
+		},
+		{
+			Name:      "depended pods running but succeeded required",
+			DependsOn: &v1alpha1.DependsOn{Name: []string{"ps"}, Phase: v1.PodSucceeded},
+			Pods:      podsOf(v1.PodRunning, v1.PodSucceeded),
+			ReturnVal: false,
+		},
+		{
+			Name:      "depended pods succeeded",
+			DependsOn: &v1alpha1.DependsOn{Name: []string{"ps"}, Phase: v1.PodSucceeded},
+			Pods:      podsOf(v1.PodSucceeded, v1.PodSucceeded),
+			ReturnVal: true,
+		},
+	}
+
+	for i, testcase := range testcases {
+		ready := isDependsOnReady(job, testcase.DependsOn, testcase.Pods)
+		if ready != testcase.ReturnVal {
+			t.Errorf("%s: expected return value to be %t, but got %t in case %d", testcase.Name, testcase.ReturnVal, ready, i)
+		}
+	}
+}
+
 func TestAddResourceList(t *testing.T) {
 	testcases := []struct {
 		Name string
@@ -745,3 +1054,60 @@ func TestTasksPriority_Swap(t *testing.T) {
 		testcase.TasksPriority.Swap(testcase.Task1Index, testcase.Task2Index)
 	}
 }
+
+func TestIsScaledDownPod(t *testing.T) {
+	job := &v1alpha1.Job{
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{
+				{Name: "worker", Replicas: 2},
+			},
+		},
+	}
+
+	podOf := func(task string, index int) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        MakePodName("job", task, index),
+				Annotations: map[string]string{v1alpha1.TaskSpecKey: task},
+			},
+		}
+	}
+
+	testcases := []struct {
+		Name      string
+		Job       *v1alpha1.Job
+		Pod       *v1.Pod
+		ReturnVal bool
+	}{
+		{
+			Name:      "pod within replicas",
+			Job:       job,
+			Pod:       podOf("worker", 1),
+			ReturnVal: false,
+		},
+		{
+			Name:      "pod beyond replicas",
+			Job:       job,
+			Pod:       podOf("worker", 2),
+			ReturnVal: true,
+		},
+		{
+			Name:      "pod of unknown task",
+			Job:       job,
+			Pod:       podOf("ps", 2),
+			ReturnVal: false,
+		},
+		{
+			Name:      "job not found",
+			Job:       nil,
+			Pod:       podOf("worker", 2),
+			ReturnVal: false,
+		},
+	}
+
+	for i, testcase := range testcases {
+		if ret := isScaledDownPod(testcase.Job, testcase.Pod); ret != testcase.ReturnVal {
+			t.Errorf("case %d (%s): expected: %v, got %v ", i, testcase.Name, testcase.ReturnVal, ret)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"k8s.io/api/core/v1"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -1132,6 +1133,121 @@ func TestRestartingState_Execute(t *testing.T) {
 	}
 }
 
+func TestRestartingState_Backoff(t *testing.T) {
+	namespace := "test"
+	now := time.Now()
+
+	testcases := []struct {
+		Name string
+		// Restarted is how long ago the job was restarted.
+		Restarted  time.Duration
+		Multiplier int32
+		// ExpectedDelay is the backoff of the second restart.
+		ExpectedDelay time.Duration
+		ExpectedPhase v1alpha1.JobPhase
+	}{
+		{
+			Name:          "RestartingState- pods are not recreated during backoff",
+			Restarted:     10 * time.Second,
+			Multiplier:    2,
+			ExpectedDelay: 20 * time.Second,
+			ExpectedPhase: v1alpha1.Restarting,
+		},
+		{
+			Name:          "RestartingState- pods are recreated after backoff",
+			Restarted:     time.Minute,
+			Multiplier:    2,
+			ExpectedDelay: 20 * time.Second,
+			ExpectedPhase: v1alpha1.Pending,
+		},
+		{
+			Name:          "RestartingState- backoff with large multiplier is capped by max delay",
+			Restarted:     10 * time.Second,
+			Multiplier:    1 << 30,
+			ExpectedDelay: 30 * time.Second,
+			ExpectedPhase: v1alpha1.Restarting,
+		},
+	}
+
+	for i, testcase := range testcases {
+		multiplier := testcase.Multiplier
+		restartTime := metav1.NewTime(now.Add(-testcase.Restarted))
+		job := &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "Job1",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.JobSpec{
+				MaxRetry: 3,
+				Tasks: []v1alpha1.TaskSpec{
+					{
+						Name:     "task1",
+						Replicas: 1,
+					},
+				},
+				RestartBackoff: &v1alpha1.RestartBackoff{
+					InitialDelay: &metav1.Duration{Duration: 10 * time.Second},
+					Multiplier:   &multiplier,
+					MaxDelay:     &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+			Status: v1alpha1.JobStatus{
+				RetryCount:   2,
+				MinAvailable: 1,
+				State: v1alpha1.JobState{
+					Phase:              v1alpha1.Restarting,
+					LastTransitionTime: restartTime,
+				},
+			},
+		}
+
+		fakecontroller := newFakeController()
+		state.KillJob = fakecontroller.killJob
+
+		if _, err := fakecontroller.vkClients.BatchV1alpha1().Jobs(namespace).Create(job); err != nil {
+			t.Errorf("Case %d: error while creating Job: %v", i, err)
+		}
+		if err := fakecontroller.cache.Add(job); err != nil {
+			t.Errorf("Case %d: error while adding Job in cache: %v", i, err)
+		}
+
+		testState := state.NewState(&apis.JobInfo{Namespace: namespace, Name: job.Name, Job: job})
+		if err := testState.Execute(v1alpha1.RestartJobAction); err != nil {
+			t.Errorf("Case %d: expected error not to occur but got: %s", i, err)
+		}
+
+		jobInfo, err := fakecontroller.cache.Get(fmt.Sprintf("%s/%s", namespace, job.Name))
+		if err != nil {
+			t.Errorf("Case %d: error while retrieving value from Cache: %v", i, err)
+			continue
+		}
+
+		status := jobInfo.Job.Status
+		if status.State.Phase != testcase.ExpectedPhase {
+			t.Errorf("Case %d (%s): expected Job phase to %s, but got %s",
+				i, testcase.Name, testcase.ExpectedPhase, status.State.Phase)
+		}
+
+		// The next retry time is cleared once the pods are recreated.
+		if testcase.ExpectedPhase == v1alpha1.Restarting {
+			expectedRetryTime := restartTime.Add(testcase.ExpectedDelay)
+			if status.NextRetryTime == nil || !status.NextRetryTime.Time.Equal(expectedRetryTime) {
+				t.Errorf("Case %d (%s): expected next retry time %v, but got %v",
+					i, testcase.Name, expectedRetryTime, status.NextRetryTime)
+			}
+		} else if status.NextRetryTime != nil {
+			t.Errorf("Case %d (%s): expected next retry time to be cleared, but got %v",
+				i, testcase.Name, status.NextRetryTime)
+		}
+
+		after := retryAfter(jobInfo.Job, now)
+		if (testcase.ExpectedPhase == v1alpha1.Restarting) != (after > 0) {
+			t.Errorf("Case %d (%s): unexpected retry after %v in phase %s",
+				i, testcase.Name, after, status.State.Phase)
+		}
+	}
+}
+
 func TestRunningState_Execute(t *testing.T) {
 	namespace := "test"
 
@@ -1420,6 +1536,51 @@ func TestRunningState_Execute(t *testing.T) {
 			Action:      v1alpha1.SyncJobAction,
 			ExpectedVal: nil,
 		},
+		{
+			Name: "RunningState- Default case and TaskCompleted policy of task scaled down to zero",
+			JobInfo: &apis.JobInfo{
+				Namespace: namespace,
+				Name:      "jobinfo1",
+				Job: &v1alpha1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "job1",
+						Namespace: namespace,
+					},
+					Spec: v1alpha1.JobSpec{
+						Tasks: []v1alpha1.TaskSpec{
+							{
+								Name:     "task1",
+								Replicas: 0,
+								Policies: []v1alpha1.LifecyclePolicy{
+									{
+										Action: v1alpha1.CompleteJobAction,
+										Event:  v1alpha1.TaskCompletedEvent,
+									},
+								},
+							},
+							{
+								Name:     "task2",
+								Replicas: 2,
+							},
+						},
+					},
+					Status: v1alpha1.JobStatus{
+						Running: 2,
+						State: v1alpha1.JobState{
+							Phase: v1alpha1.Running,
+						},
+					},
+				},
+				Pods: map[string]map[string]*v1.Pod{
+					"task2": {
+						"job1-task2-0": buildPod(namespace, "pod1", v1.PodRunning, nil),
+						"job1-task2-1": buildPod(namespace, "pod2", v1.PodRunning, nil),
+					},
+				},
+			},
+			Action:      v1alpha1.SyncJobAction,
+			ExpectedVal: nil,
+		},
 	}
 
 	for i, testcase := range testcases {
@@ -41,4 +41,7 @@ type PluginInterface interface {
 
 	// do once when killJob
 	OnJobDelete(job *vkv1.Job) error
+
+	// do once when syncJob scales the replicas of tasks
+	OnJobUpdate(job *vkv1.Job) error
 }
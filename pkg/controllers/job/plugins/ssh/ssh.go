@@ -93,6 +93,10 @@ func (sp *sshPlugin) OnJobDelete(job *vkv1.Job) error {
 	return nil
 }
 
+func (sp *sshPlugin) OnJobUpdate(job *vkv1.Job) error {
+	return nil
+}
+
 func (sp *sshPlugin) mountRsaKey(pod *v1.Pod, job *vkv1.Job) {
 	sshPath := SSHAbsolutePath
 	if sp.noRoot {
@@ -100,6 +100,18 @@ func (sp *servicePlugin) OnJobDelete(job *vkv1.Job) error {
 	return nil
 }
 
+// OnJobUpdate refreshes the hosts of tasks, so that peers could find the
+// pods added or removed by scaling.
+func (sp *servicePlugin) OnJobUpdate(job *vkv1.Job) error {
+	data := generateHost(job)
+
+	if err := helpers.CreateConfigMapIfNotExist(job, sp.Clientset.KubeClients, data, sp.cmName(job)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (sp *servicePlugin) mountConfigmap(pod *v1.Pod, job *vkv1.Job) {
 	cmName := sp.cmName(job)
 	cmVolume := v1.Volume{
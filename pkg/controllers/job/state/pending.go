@@ -62,8 +62,11 @@ func (ps *pendingState) Execute(action vkv1.Action) error {
 		})
 	default:
 		return CreateJob(ps.job, func(status *vkv1.JobStatus) bool {
+			// Keep the transition time of pending job, which is used
+			// by its pendingTimeout.
+			phaseChanged := status.State.Phase != vkv1.Pending
 			status.State.Phase = vkv1.Pending
-			return true
+			return phaseChanged
 		})
 	}
 }
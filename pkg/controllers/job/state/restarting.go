@@ -17,6 +17,10 @@ limitations under the License.
 package state
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	vkv1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
 	"volcano.sh/volcano/pkg/controllers/apis"
 )
@@ -36,8 +40,20 @@ func (ps *restartingState) Execute(action vkv1.Action) error {
 		if status.RetryCount >= maxRetry {
 			// Failed is the phase that the job is restarted failed reached the maximum number of retries.
 			status.State.Phase = vkv1.Failed
+			status.NextRetryTime = nil
 			return true
 		}
+
+		// Wait for the backoff, counted from the restart, before the pods
+		// are recreated; the job is synced again at NextRetryTime.
+		if delay := restartDelay(ps.job.Job, status.RetryCount); delay > 0 {
+			nextRetryTime := metav1.NewTime(status.State.LastTransitionTime.Add(delay))
+			status.NextRetryTime = &nextRetryTime
+			if time.Now().Before(nextRetryTime.Time) {
+				return false
+			}
+		}
+
 		total := int32(0)
 		for _, task := range ps.job.Job.Spec.Tasks {
 			total += task.Replicas
@@ -45,6 +61,7 @@ func (ps *restartingState) Execute(action vkv1.Action) error {
 
 		if total-status.Terminating >= status.MinAvailable {
 			status.State.Phase = vkv1.Pending
+			status.NextRetryTime = nil
 			return true
 		}
 
@@ -49,6 +49,13 @@ func (ps *runningState) Execute(action vkv1.Action) error {
 			return true
 		})
 	default:
+		// The job may complete before all its pods finish, e.g. once the
+		// master task succeeds, according to the TaskCompleted policies.
+		switch action := taskCompletedAction(ps.job); action {
+		case vkv1.RestartJobAction, vkv1.AbortJobAction, vkv1.TerminateJobAction, vkv1.CompleteJobAction:
+			return ps.Execute(action)
+		}
+
 		return SyncJob(ps.job, func(status *vkv1.JobStatus) bool {
 			if status.Succeeded+status.Failed == TotalTasks(ps.job.Job) {
 				status.State.Phase = vkv1.Completed
@@ -17,12 +17,26 @@ limitations under the License.
 package state
 
 import (
+	"time"
+
+	"k8s.io/api/core/v1"
+
 	vkv1 "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
 )
 
 //DefaultMaxRetry is the default number of retries.
 const DefaultMaxRetry int32 = 3
 
+const (
+	// DefaultRestartInitialDelay is the default delay of the first restart.
+	DefaultRestartInitialDelay = 10 * time.Second
+	// DefaultRestartMultiplier is the default factor of the restart delay.
+	DefaultRestartMultiplier int32 = 2
+	// DefaultRestartMaxDelay is the default maximum delay of restarts.
+	DefaultRestartMaxDelay = 5 * time.Minute
+)
+
 //TotalTasks returns number of tasks in a given volcano job
 func TotalTasks(job *vkv1.Job) int32 {
 	var rep int32
@@ -33,3 +47,95 @@ func TotalTasks(job *vkv1.Job) int32 {
 
 	return rep
 }
+
+// taskCompletedAction returns the action of the first TaskCompleted policy,
+// task level ones go first, whose task has enough succeeded pods; it's empty
+// if no such policy.
+func taskCompletedAction(job *apis.JobInfo) vkv1.Action {
+	for _, task := range job.Job.Spec.Tasks {
+		for _, policy := range task.Policies {
+			if taskCompleted(job, task, policy) {
+				return policy.Action
+			}
+		}
+	}
+
+	for _, policy := range job.Job.Spec.Policies {
+		for _, task := range job.Job.Spec.Tasks {
+			if len(policy.TaskName) != 0 && policy.TaskName != task.Name {
+				continue
+			}
+			if taskCompleted(job, task, policy) {
+				return policy.Action
+			}
+		}
+	}
+
+	return ""
+}
+
+// taskCompleted returns whether the policy is for TaskCompleted event, and
+// the succeeded pods of task reach its MinSucceeded, or the task replicas.
+// A task scaled down to zero replicas is never completed.
+func taskCompleted(job *apis.JobInfo, task vkv1.TaskSpec, policy vkv1.LifecyclePolicy) bool {
+	found := policy.Event == vkv1.TaskCompletedEvent
+	for _, event := range policy.Events {
+		found = found || event == vkv1.TaskCompletedEvent
+	}
+	if !found {
+		return false
+	}
+
+	minSucceeded := task.Replicas
+	if policy.MinSucceeded != nil {
+		minSucceeded = *policy.MinSucceeded
+	}
+	if minSucceeded <= 0 {
+		return false
+	}
+
+	var succeeded int32
+	for _, pod := range job.Pods[task.Name] {
+		if pod.Status.Phase == v1.PodSucceeded {
+			succeeded++
+		}
+	}
+
+	return succeeded >= minSucceeded
+}
+
+// restartDelay returns the backoff before the pods of job are recreated in
+// its retryCount-th restart; it's zero if job has no restartBackoff.
+func restartDelay(job *vkv1.Job, retryCount int32) time.Duration {
+	backoff := job.Spec.RestartBackoff
+	if backoff == nil {
+		return 0
+	}
+
+	delay := DefaultRestartInitialDelay
+	if backoff.InitialDelay != nil {
+		delay = backoff.InitialDelay.Duration
+	}
+	multiplier := DefaultRestartMultiplier
+	if backoff.Multiplier != nil {
+		multiplier = *backoff.Multiplier
+	}
+	maxDelay := DefaultRestartMaxDelay
+	if backoff.MaxDelay != nil {
+		maxDelay = backoff.MaxDelay.Duration
+	}
+
+	for i := int32(1); i < retryCount && delay < maxDelay; i++ {
+		// Clamp before multiplying, or a large multiplier overflows the delay.
+		if multiplier > 1 && delay > maxDelay/time.Duration(multiplier) {
+			delay = maxDelay
+			break
+		}
+		delay *= time.Duration(multiplier)
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobflow
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	batch "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	flow "volcano.sh/volcano/pkg/apis/flow/v1alpha1"
+	"volcano.sh/volcano/pkg/apis/helpers"
+	vkclientset "volcano.sh/volcano/pkg/client/clientset/versioned"
+	vkinformerfactory "volcano.sh/volcano/pkg/client/informers/externalversions"
+	vkbatchinformer "volcano.sh/volcano/pkg/client/informers/externalversions/batch/v1alpha1"
+	vkflowinformer "volcano.sh/volcano/pkg/client/informers/externalversions/flow/v1alpha1"
+	vkbatchlister "volcano.sh/volcano/pkg/client/listers/batch/v1alpha1"
+	vkflowlister "volcano.sh/volcano/pkg/client/listers/flow/v1alpha1"
+)
+
+// Controller creates the Jobs of JobFlows once their dependencies are
+// satisfied, and aggregates the phases of the Jobs into JobFlow status.
+type Controller struct {
+	vkClient vkclientset.Interface
+
+	// informer
+	jobFlowInformer vkflowinformer.JobFlowInformer
+	jobInformer     vkbatchinformer.JobInformer
+
+	// jobFlowLister
+	jobFlowLister vkflowlister.JobFlowLister
+	jobFlowSynced cache.InformerSynced
+
+	// jobLister
+	jobLister vkbatchlister.JobLister
+	jobSynced cache.InformerSynced
+
+	// JobFlows that need to be synced.
+	queue workqueue.RateLimitingInterface
+}
+
+// NewJobFlowController creates a JobFlowController
+func NewJobFlowController(vkClient vkclientset.Interface) *Controller {
+	factory := vkinformerfactory.NewSharedInformerFactory(vkClient, 0)
+	jobFlowInformer := factory.Flow().V1alpha1().JobFlows()
+	jobInformer := factory.Batch().V1alpha1().Jobs()
+
+	c := &Controller{
+		vkClient: vkClient,
+
+		jobFlowInformer: jobFlowInformer,
+		jobInformer:     jobInformer,
+
+		jobFlowLister: jobFlowInformer.Lister(),
+		jobFlowSynced: jobFlowInformer.Informer().HasSynced,
+
+		jobLister: jobInformer.Lister(),
+		jobSynced: jobInformer.Informer().HasSynced,
+
+		queue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	jobFlowInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addJobFlow,
+		UpdateFunc: c.updateJobFlow,
+	})
+
+	jobInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			return helpers.ControlledBy(obj, helpers.JobFlowKind)
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addJob,
+			UpdateFunc: c.updateJob,
+			DeleteFunc: c.deleteJob,
+		},
+	})
+
+	return c
+}
+
+// Run starts JobFlowController
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	go c.jobFlowInformer.Informer().Run(stopCh)
+	go c.jobInformer.Informer().Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, c.jobFlowSynced, c.jobSynced) {
+		glog.Errorf("unable to sync caches for job flow controller")
+		return
+	}
+
+	go wait.Until(c.worker, 0, stopCh)
+	glog.Infof("JobFlowController is running ...... ")
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	eKey, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(eKey)
+
+	if err := c.syncJobFlow(eKey.(string)); err != nil {
+		glog.V(2).Infof("Error syncing job flow %q, retrying. Error: %v", eKey, err)
+		c.queue.AddRateLimited(eKey)
+		return true
+	}
+
+	c.queue.Forget(eKey)
+	return true
+}
+
+func (c *Controller) syncJobFlow(key string) error {
+	glog.V(4).Infof("Begin sync job flow %s", key)
+	defer glog.V(4).Infof("End sync job flow %s", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	jobFlow, err := c.jobFlowLister.JobFlows(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			glog.V(2).Infof("job flow %s has been deleted", key)
+			return nil
+		}
+		return err
+	}
+
+	if jobFlow.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if err := validateFlows(jobFlow.Spec.Flows); err != nil {
+		return c.updateStatus(jobFlow, flow.JobFlowState{
+			Phase:   flow.Failed,
+			Reason:  "InvalidFlows",
+			Message: err.Error(),
+		}, nil)
+	}
+
+	// The phases recorded in status are kept for the Jobs which were deleted,
+	// e.g. by ttlSecondsAfterFinished, so that finished flows never run again.
+	phases := map[string]batch.JobPhase{}
+	for _, status := range jobFlow.Status.JobStatuses {
+		if len(status.Phase) != 0 {
+			phases[status.Name] = status.Phase
+		}
+	}
+	for _, f := range jobFlow.Spec.Flows {
+		job, err := c.jobLister.Jobs(namespace).Get(MakeJobName(jobFlow.Name, f.Name))
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if !metav1.IsControlledBy(job, jobFlow) {
+			return c.updateStatus(jobFlow, flow.JobFlowState{
+				Phase:   flow.Failed,
+				Reason:  "JobConflict",
+				Message: fmt.Sprintf("Job %s/%s is not controlled by the JobFlow", job.Namespace, job.Name),
+			}, nil)
+		}
+
+		phases[f.Name] = job.Status.State.Phase
+		if phases[f.Name] == "" {
+			phases[f.Name] = batch.Pending
+		}
+	}
+
+	// Do not start new Jobs once the flow failed.
+	if state := jobFlowState(jobFlow.Spec.Flows, phases); state.Phase != flow.Failed {
+		for _, f := range jobFlow.Spec.Flows {
+			if _, found := phases[f.Name]; found || !isDependsOnReady(f.DependsOn, phases) {
+				continue
+			}
+
+			if err := c.createJob(jobFlow, f); err != nil {
+				return err
+			}
+			phases[f.Name] = batch.Pending
+		}
+	}
+
+	jobStatuses := make([]flow.JobStatus, 0, len(jobFlow.Spec.Flows))
+	for _, f := range jobFlow.Spec.Flows {
+		status := flow.JobStatus{Name: f.Name}
+		if phase, found := phases[f.Name]; found {
+			status.JobName = MakeJobName(jobFlow.Name, f.Name)
+			status.Phase = phase
+		}
+		jobStatuses = append(jobStatuses, status)
+	}
+
+	return c.updateStatus(jobFlow, jobFlowState(jobFlow.Spec.Flows, phases), jobStatuses)
+}
+
+func (c *Controller) createJob(jobFlow *flow.JobFlow, f flow.Flow) error {
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeJobName(jobFlow.Name, f.Name),
+			Namespace: jobFlow.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(jobFlow, helpers.JobFlowKind),
+			},
+			Labels: map[string]string{
+				flow.JobFlowNameKey: jobFlow.Name,
+				flow.FlowNameKey:    f.Name,
+			},
+		},
+		Spec: *f.Template.DeepCopy(),
+	}
+
+	if _, err := c.vkClient.BatchV1alpha1().Jobs(job.Namespace).Create(job); err != nil && !apierrors.IsAlreadyExists(err) {
+		glog.Errorf("Failed to create Job %s/%s of JobFlow %s: %v", job.Namespace, job.Name, jobFlow.Name, err)
+		return err
+	}
+
+	glog.V(3).Infof("Created Job <%s/%s> of JobFlow %s", job.Namespace, job.Name, jobFlow.Name)
+	return nil
+}
+
+func (c *Controller) updateStatus(jobFlow *flow.JobFlow, state flow.JobFlowState, jobStatuses []flow.JobStatus) error {
+	// ignore update when status doesnot change
+	oldState := jobFlow.Status.State
+	if oldState.Phase == state.Phase && oldState.Reason == state.Reason && oldState.Message == state.Message &&
+		reflect.DeepEqual(jobFlow.Status.JobStatuses, jobStatuses) {
+		return nil
+	}
+
+	state.LastTransitionTime = oldState.LastTransitionTime
+	if oldState.Phase != state.Phase {
+		state.LastTransitionTime = metav1.Now()
+	}
+
+	newJobFlow := jobFlow.DeepCopy()
+	newJobFlow.Status.State = state
+	newJobFlow.Status.JobStatuses = jobStatuses
+
+	if _, err := c.vkClient.FlowV1alpha1().JobFlows(newJobFlow.Namespace).UpdateStatus(newJobFlow); err != nil {
+		glog.Errorf("Failed to update status of JobFlow %s/%s: %v", newJobFlow.Namespace, newJobFlow.Name, err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) enqueue(jobFlow *flow.JobFlow) {
+	key, err := cache.MetaNamespaceKeyFunc(jobFlow)
+	if err != nil {
+		glog.Errorf("couldn't get key for object %#v: %v", jobFlow, err)
+		return
+	}
+
+	c.queue.Add(key)
+}
+
+func (c *Controller) addJobFlow(obj interface{}) {
+	jobFlow := obj.(*flow.JobFlow)
+	c.enqueue(jobFlow)
+}
+
+func (c *Controller) updateJobFlow(old, new interface{}) {
+	oldJobFlow := old.(*flow.JobFlow)
+	newJobFlow := new.(*flow.JobFlow)
+
+	if oldJobFlow.ResourceVersion == newJobFlow.ResourceVersion {
+		return
+	}
+
+	c.enqueue(newJobFlow)
+}
+
+// enqueueController enqueues the JobFlow which controls the Job.
+func (c *Controller) enqueueController(job *batch.Job) {
+	controllerRef := metav1.GetControllerOf(job)
+	if controllerRef == nil || controllerRef.Kind != helpers.JobFlowKind.Kind {
+		return
+	}
+
+	c.queue.Add(job.Namespace + "/" + controllerRef.Name)
+}
+
+func (c *Controller) addJob(obj interface{}) {
+	job := obj.(*batch.Job)
+	c.enqueueController(job)
+}
+
+func (c *Controller) updateJob(old, new interface{}) {
+	oldJob := old.(*batch.Job)
+	newJob := new.(*batch.Job)
+
+	if oldJob.Status.State.Phase != newJob.Status.State.Phase {
+		c.enqueueController(newJob)
+	}
+}
+
+func (c *Controller) deleteJob(obj interface{}) {
+	job, ok := obj.(*batch.Job)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			glog.Errorf("Couldn't get object from tombstone %#v", obj)
+			return
+		}
+		job, ok = tombstone.Obj.(*batch.Job)
+		if !ok {
+			glog.Errorf("Tombstone contained object that is not a Job: %#v", obj)
+			return
+		}
+	}
+
+	c.enqueueController(job)
+}
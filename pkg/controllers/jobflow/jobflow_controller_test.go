@@ -0,0 +1,300 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobflow
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	flow "volcano.sh/volcano/pkg/apis/flow/v1alpha1"
+	"volcano.sh/volcano/pkg/apis/helpers"
+	vkclient "volcano.sh/volcano/pkg/client/clientset/versioned/fake"
+)
+
+func newPipeline() *flow.JobFlow {
+	return &flow.JobFlow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipeline",
+			Namespace: "test",
+			UID:       "pipeline-uid",
+		},
+		Spec: flow.JobFlowSpec{
+			Flows: []flow.Flow{
+				{Name: "preprocess"},
+				{Name: "train", DependsOn: &flow.DependsOn{Targets: []string{"preprocess"}}},
+				{Name: "evaluate", DependsOn: &flow.DependsOn{Targets: []string{"train"}}},
+			},
+		},
+	}
+}
+
+func newFlowJob(jobFlow *flow.JobFlow, flowName string, phase batch.JobPhase) *batch.Job {
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeJobName(jobFlow.Name, flowName),
+			Namespace: jobFlow.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(jobFlow, helpers.JobFlowKind),
+			},
+		},
+		Status: batch.JobStatus{
+			State: batch.JobState{Phase: phase},
+		},
+	}
+}
+
+func TestValidateFlows(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Flows     []flow.Flow
+		ExpectErr bool
+	}{
+		{
+			Name:      "valid flows",
+			Flows:     newPipeline().Spec.Flows,
+			ExpectErr: false,
+		},
+		{
+			Name:      "no flows",
+			Flows:     nil,
+			ExpectErr: true,
+		},
+		{
+			Name:      "duplicated flow name",
+			Flows:     []flow.Flow{{Name: "a"}, {Name: "a"}},
+			ExpectErr: true,
+		},
+		{
+			Name: "unknown depended flow",
+			Flows: []flow.Flow{
+				{Name: "a", DependsOn: &flow.DependsOn{Targets: []string{"b"}}},
+			},
+			ExpectErr: true,
+		},
+		{
+			Name: "invalid dependsOn phase",
+			Flows: []flow.Flow{
+				{Name: "a"},
+				{Name: "b", DependsOn: &flow.DependsOn{Targets: []string{"a"}, Phase: batch.Pending}},
+			},
+			ExpectErr: true,
+		},
+		{
+			Name: "circular dependency",
+			Flows: []flow.Flow{
+				{Name: "a", DependsOn: &flow.DependsOn{Targets: []string{"c"}}},
+				{Name: "b", DependsOn: &flow.DependsOn{Targets: []string{"a"}}},
+				{Name: "c", DependsOn: &flow.DependsOn{Targets: []string{"b"}}},
+			},
+			ExpectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := validateFlows(testCase.Flows)
+		if testCase.ExpectErr != (err != nil) {
+			t.Errorf("%s: expected error %t, but got %v", testCase.Name, testCase.ExpectErr, err)
+		}
+	}
+}
+
+func TestJobFlowState(t *testing.T) {
+	flows := newPipeline().Spec.Flows
+
+	testCases := []struct {
+		Name        string
+		Phases      map[string]batch.JobPhase
+		ExpectPhase flow.JobFlowPhase
+	}{
+		{
+			Name:        "no job created",
+			Phases:      map[string]batch.JobPhase{},
+			ExpectPhase: flow.Pending,
+		},
+		{
+			Name:        "some jobs created",
+			Phases:      map[string]batch.JobPhase{"preprocess": batch.Completed, "train": batch.Running},
+			ExpectPhase: flow.Running,
+		},
+		{
+			Name:        "some job failed",
+			Phases:      map[string]batch.JobPhase{"preprocess": batch.Completed, "train": batch.Failed},
+			ExpectPhase: flow.Failed,
+		},
+		{
+			Name:        "some job aborted",
+			Phases:      map[string]batch.JobPhase{"preprocess": batch.Completed, "train": batch.Aborted},
+			ExpectPhase: flow.Failed,
+		},
+		{
+			Name: "all jobs completed",
+			Phases: map[string]batch.JobPhase{
+				"preprocess": batch.Completed, "train": batch.Completed, "evaluate": batch.Completed,
+			},
+			ExpectPhase: flow.Succeeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		if state := jobFlowState(flows, testCase.Phases); state.Phase != testCase.ExpectPhase {
+			t.Errorf("%s: expected phase %s, but got %s", testCase.Name, testCase.ExpectPhase, state.Phase)
+		}
+	}
+}
+
+func TestIsDependsOnReady(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		DependsOn   *flow.DependsOn
+		Phases      map[string]batch.JobPhase
+		ExpectReady bool
+	}{
+		{
+			Name:        "no dependency",
+			DependsOn:   nil,
+			ExpectReady: true,
+		},
+		{
+			Name:        "depended job not created",
+			DependsOn:   &flow.DependsOn{Targets: []string{"a"}},
+			Phases:      map[string]batch.JobPhase{},
+			ExpectReady: false,
+		},
+		{
+			Name:        "depended job running but completed required",
+			DependsOn:   &flow.DependsOn{Targets: []string{"a"}},
+			Phases:      map[string]batch.JobPhase{"a": batch.Running},
+			ExpectReady: false,
+		},
+		{
+			Name:        "depended job running",
+			DependsOn:   &flow.DependsOn{Targets: []string{"a"}, Phase: batch.Running},
+			Phases:      map[string]batch.JobPhase{"a": batch.Running},
+			ExpectReady: true,
+		},
+		{
+			Name:        "depended jobs completed",
+			DependsOn:   &flow.DependsOn{Targets: []string{"a", "b"}},
+			Phases:      map[string]batch.JobPhase{"a": batch.Completed, "b": batch.Completed},
+			ExpectReady: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		if ready := isDependsOnReady(testCase.DependsOn, testCase.Phases); ready != testCase.ExpectReady {
+			t.Errorf("%s: expected ready %t, but got %t", testCase.Name, testCase.ExpectReady, ready)
+		}
+	}
+}
+
+func TestSyncJobFlow(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Jobs []batch.JobPhase
+		// Recorded are the phases of flows recorded in status.
+		Recorded    map[string]batch.JobPhase
+		ExpectJobs  []string
+		ExpectPhase flow.JobFlowPhase
+	}{
+		{
+			Name:        "create jobs without dependency",
+			Jobs:        nil,
+			ExpectJobs:  []string{"pipeline-preprocess"},
+			ExpectPhase: flow.Running,
+		},
+		{
+			Name:        "wait for depended job completed",
+			Jobs:        []batch.JobPhase{batch.Running},
+			ExpectJobs:  []string{"pipeline-preprocess"},
+			ExpectPhase: flow.Running,
+		},
+		{
+			Name:        "create job after depended job completed",
+			Jobs:        []batch.JobPhase{batch.Completed},
+			ExpectJobs:  []string{"pipeline-preprocess", "pipeline-train"},
+			ExpectPhase: flow.Running,
+		},
+		{
+			Name:        "stop creating jobs after job failed",
+			Jobs:        []batch.JobPhase{batch.Failed},
+			ExpectJobs:  []string{"pipeline-preprocess"},
+			ExpectPhase: flow.Failed,
+		},
+		{
+			Name:        "do not recreate deleted job which completed",
+			Jobs:        nil,
+			Recorded:    map[string]batch.JobPhase{"preprocess": batch.Completed},
+			ExpectJobs:  []string{"pipeline-train"},
+			ExpectPhase: flow.Running,
+		},
+	}
+
+	for _, testCase := range testCases {
+		jobFlow := newPipeline()
+		for _, f := range jobFlow.Spec.Flows {
+			if phase, found := testCase.Recorded[f.Name]; found {
+				jobFlow.Status.JobStatuses = append(jobFlow.Status.JobStatuses, flow.JobStatus{
+					Name:    f.Name,
+					JobName: MakeJobName(jobFlow.Name, f.Name),
+					Phase:   phase,
+				})
+			}
+		}
+		vkClient := vkclient.NewSimpleClientset()
+		c := NewJobFlowController(vkClient)
+
+		if _, err := vkClient.FlowV1alpha1().JobFlows(jobFlow.Namespace).Create(jobFlow); err != nil {
+			t.Fatalf("%s: failed to create job flow: %v", testCase.Name, err)
+		}
+
+		c.jobFlowInformer.Informer().GetIndexer().Add(jobFlow)
+		for i, phase := range testCase.Jobs {
+			job := newFlowJob(jobFlow, jobFlow.Spec.Flows[i].Name, phase)
+			if _, err := vkClient.BatchV1alpha1().Jobs(job.Namespace).Create(job); err != nil {
+				t.Fatalf("%s: failed to create job: %v", testCase.Name, err)
+			}
+			c.jobInformer.Informer().GetIndexer().Add(job)
+		}
+
+		if err := c.syncJobFlow("test/pipeline"); err != nil {
+			t.Errorf("%s: failed to sync job flow: %v", testCase.Name, err)
+			continue
+		}
+
+		var jobs []string
+		for _, f := range jobFlow.Spec.Flows {
+			name := MakeJobName(jobFlow.Name, f.Name)
+			if _, err := vkClient.BatchV1alpha1().Jobs("test").Get(name, metav1.GetOptions{}); err == nil {
+				jobs = append(jobs, name)
+			}
+		}
+		if !reflect.DeepEqual(jobs, testCase.ExpectJobs) {
+			t.Errorf("%s: expected jobs %v, but got %v", testCase.Name, testCase.ExpectJobs, jobs)
+		}
+
+		newJobFlow, err := vkClient.FlowV1alpha1().JobFlows("test").Get("pipeline", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("%s: failed to get job flow: %v", testCase.Name, err)
+		}
+		if newJobFlow.Status.State.Phase != testCase.ExpectPhase {
+			t.Errorf("%s: expected phase %s, but got %s", testCase.Name, testCase.ExpectPhase, newJobFlow.Status.State.Phase)
+		}
+	}
+}
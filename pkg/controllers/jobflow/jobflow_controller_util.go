@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobflow
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	batch "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	flow "volcano.sh/volcano/pkg/apis/flow/v1alpha1"
+	vkjobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+// MakeJobName returns the name of the Job created for the flow of JobFlow
+func MakeJobName(jobFlowName string, flowName string) string {
+	return fmt.Sprintf("%s-%s", jobFlowName, flowName)
+}
+
+// validateFlows checks that flow names are unique, depended flows exist and
+// there is no circular dependency.
+func validateFlows(flows []flow.Flow) error {
+	if len(flows) == 0 {
+		return fmt.Errorf("no flow specified in job flow spec")
+	}
+
+	dependsOn := map[string][]string{}
+	for _, f := range flows {
+		if errMsgs := validation.IsDNS1123Label(f.Name); len(errMsgs) > 0 {
+			return fmt.Errorf("invalid flow name %s: %v", f.Name, errMsgs)
+		}
+		if _, found := dependsOn[f.Name]; found {
+			return fmt.Errorf("duplicated flow name %s", f.Name)
+		}
+		dependsOn[f.Name] = nil
+	}
+
+	for _, f := range flows {
+		if f.DependsOn == nil {
+			continue
+		}
+
+		if phase := f.DependsOn.Phase; phase != "" && phase != batch.Running && phase != batch.Completed {
+			return fmt.Errorf("invalid dependsOn phase %s in flow %s, valid phases are %v",
+				phase, f.Name, []batch.JobPhase{batch.Running, batch.Completed})
+		}
+
+		for _, target := range f.DependsOn.Targets {
+			if target == f.Name {
+				return fmt.Errorf("flow %s can not depend on itself", f.Name)
+			}
+			if _, found := dependsOn[target]; !found {
+				return fmt.Errorf("flow %s depends on unknown flow %s", f.Name, target)
+			}
+			dependsOn[f.Name] = append(dependsOn[f.Name], target)
+		}
+	}
+
+	var names []string
+	for _, f := range flows {
+		names = append(names, f.Name)
+	}
+	if name, found := vkjobhelpers.FindDependencyCycle(names, dependsOn); found {
+		return fmt.Errorf("circular dependency found in flow %s", name)
+	}
+
+	return nil
+}
+
+// isDependsOnReady checks whether the Jobs of depended flows have reached
+// the phase required by dependsOn.
+func isDependsOnReady(dependsOn *flow.DependsOn, phases map[string]batch.JobPhase) bool {
+	if dependsOn == nil {
+		return true
+	}
+
+	for _, target := range dependsOn.Targets {
+		switch phases[target] {
+		case batch.Completed:
+			continue
+		case batch.Running, batch.Completing:
+			if dependsOn.Phase == batch.Running {
+				continue
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// jobFlowState aggregates the phases of the Jobs of flows into the state of JobFlow.
+func jobFlowState(flows []flow.Flow, phases map[string]batch.JobPhase) flow.JobFlowState {
+	var created, completed int
+	for _, f := range flows {
+		phase, found := phases[f.Name]
+		if !found {
+			continue
+		}
+		created++
+
+		switch phase {
+		case batch.Completed:
+			completed++
+		case batch.Failed, batch.Terminated, batch.Aborted:
+			return flow.JobFlowState{
+				Phase:   flow.Failed,
+				Reason:  "JobFailed",
+				Message: fmt.Sprintf("Job of flow %s is %s", f.Name, phase),
+			}
+		}
+	}
+
+	switch {
+	case completed == len(flows):
+		return flow.JobFlowState{Phase: flow.Succeeded}
+	case created != 0:
+		return flow.JobFlowState{Phase: flow.Running}
+	}
+
+	return flow.JobFlowState{Phase: flow.Pending}
+}
@@ -84,6 +84,7 @@ func NewQueueController(
 
 	queueInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addQueue,
+		UpdateFunc: c.updateQueue,
 		DeleteFunc: c.deleteQueue,
 	})
 
@@ -142,11 +143,6 @@ func (c *Controller) syncQueue(key string) error {
 
 	var pending, running, unknown int32
 	c.pgMutex.RLock()
-	if c.podGroups[key] == nil {
-		c.pgMutex.RUnlock()
-		glog.V(2).Infof("queue %s has not been seen or deleted", key)
-		return nil
-	}
 	podGroups := make([]string, 0, len(c.podGroups[key]))
 	for pgKey := range c.podGroups[key] {
 		podGroups = append(podGroups, pgKey)
@@ -181,9 +177,12 @@ func (c *Controller) syncQueue(key string) error {
 		return err
 	}
 
-	glog.V(4).Infof("queue %s jobs pending %d, running %d, unknown %d", key, pending, running, unknown)
+	state := queueState(queue, len(podGroups))
+
+	glog.V(4).Infof("queue %s jobs pending %d, running %d, unknown %d, state %s", key, pending, running, unknown, state)
 	// ignore update when status doesnot change
-	if pending == queue.Status.Pending && running == queue.Status.Running && unknown == queue.Status.Unknown {
+	if pending == queue.Status.Pending && running == queue.Status.Running && unknown == queue.Status.Unknown &&
+		state == queue.Status.State {
 		return nil
 	}
 
@@ -191,6 +190,7 @@ func (c *Controller) syncQueue(key string) error {
 	newQueue.Status.Pending = pending
 	newQueue.Status.Running = running
 	newQueue.Status.Unknown = unknown
+	newQueue.Status.State = state
 
 	if _, err := c.kbClient.SchedulingV1alpha1().Queues().UpdateStatus(newQueue); err != nil {
 		glog.Errorf("Failed to update status of Queue %s: %v", newQueue.Name, err)
@@ -200,11 +200,35 @@ func (c *Controller) syncQueue(key string) error {
 	return nil
 }
 
+// queueState returns the state of queue according to its desired state and
+// the number of PodGroups in it.
+func queueState(queue *kbv1alpha1.Queue, podGroups int) kbv1alpha1.QueueState {
+	if queue.Spec.State != kbv1alpha1.QueueStateClosed {
+		return kbv1alpha1.QueueStateOpen
+	}
+
+	// Keep closing until all PodGroups in the queue are drained.
+	if podGroups != 0 {
+		return kbv1alpha1.QueueStateClosing
+	}
+
+	return kbv1alpha1.QueueStateClosed
+}
+
 func (c *Controller) addQueue(obj interface{}) {
 	queue := obj.(*kbv1alpha1.Queue)
 	c.queue.Add(queue.Name)
 }
 
+func (c *Controller) updateQueue(old, new interface{}) {
+	oldQueue := old.(*kbv1alpha1.Queue)
+	newQueue := new.(*kbv1alpha1.Queue)
+
+	if oldQueue.Spec.State != newQueue.Spec.State {
+		c.queue.Add(newQueue.Name)
+	}
+}
+
 func (c *Controller) deleteQueue(obj interface{}) {
 	queue, ok := obj.(*kbv1alpha1.Queue)
 	if !ok {
@@ -279,6 +279,50 @@ func TestSyncQueue(t *testing.T) {
 
 }
 
+func TestQueueState(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		state       kbv1alpha1.QueueState
+		podGroups   int
+		ExpectValue kbv1alpha1.QueueState
+	}{
+		{
+			Name:        "default state",
+			state:       "",
+			podGroups:   1,
+			ExpectValue: kbv1alpha1.QueueStateOpen,
+		},
+		{
+			Name:        "closing with podgroups",
+			state:       kbv1alpha1.QueueStateClosed,
+			podGroups:   1,
+			ExpectValue: kbv1alpha1.QueueStateClosing,
+		},
+		{
+			Name:        "closed without podgroups",
+			state:       kbv1alpha1.QueueStateClosed,
+			podGroups:   0,
+			ExpectValue: kbv1alpha1.QueueStateClosed,
+		},
+	}
+
+	for i, testcase := range testCases {
+		queue := &kbv1alpha1.Queue{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "c1",
+			},
+			Spec: kbv1alpha1.QueueSpec{
+				Weight: 1,
+				State:  testcase.state,
+			},
+		}
+
+		if state := queueState(queue, testcase.podGroups); state != testcase.ExpectValue {
+			t.Errorf("case %d (%s): expected: %v, got %v ", i, testcase.Name, testcase.ExpectValue, state)
+		}
+	}
+}
+
 func TestProcessNextWorkItem(t *testing.T) {
 	testCases := []struct {
 		Name        string
@@ -45,6 +45,7 @@ func (alloc *allocateAction) Execute(ssn *framework.Session) {
 
 	queues := util.NewPriorityQueue(ssn.QueueOrderFn)
 	jobsMap := map[api.QueueID]*util.PriorityQueue{}
+	reservation := framework.GetReservation()
 
 	for _, job := range ssn.Jobs {
 		if job.PodGroup.Status.Phase == v1alpha1.PodGroupPending {
@@ -67,6 +68,10 @@ func (alloc *allocateAction) Execute(ssn *framework.Session) {
 			jobsMap[job.Queue] = util.NewPriorityQueue(ssn.JobOrderFn)
 		}
 
+		if ssn.JobStarving(job) {
+			reservation.StarvingJobs[job.UID] = true
+		}
+
 		glog.V(4).Infof("Added Job <%s/%s> into Queue <%s>", job.Namespace, job.Name, job.Queue)
 		jobsMap[job.Queue].Push(job)
 	}
@@ -77,7 +82,8 @@ func (alloc *allocateAction) Execute(ssn *framework.Session) {
 
 	allNodes := util.GetNodeList(ssn.Nodes)
 
-	predicateFn := func(task *api.TaskInfo, node *api.NodeInfo) error {
+	// Nodes locked by reserve action are kept for the target job.
+	predicateFn := reservation.PredicateFn(func(task *api.TaskInfo, node *api.NodeInfo) error {
 		// Check for Resource Predicate
 		// TODO: We could not allocate resource to task from both node.Idle and node.Releasing now,
 		// after it is done, we could change the following compare to:
@@ -85,12 +91,12 @@ func (alloc *allocateAction) Execute(ssn *framework.Session) {
 		// if !task.InitResreq.LessEqual(clonedNode.Add(node.Releasing)) {
 		//    ...
 		// }
-		if !task.InitResreq.LessEqual(node.Idle) && !task.InitResreq.LessEqual(node.Releasing) {
+		if !reservation.FitIdle(task, node) && !task.InitResreq.LessEqual(node.Releasing) {
 			return api.NewFitError(task, node, api.NodeResourceFitFailed)
 		}
 
 		return ssn.PredicateFn(task, node)
-	}
+	})
 
 	for {
 		if queues.Empty() {
@@ -148,7 +154,7 @@ func (alloc *allocateAction) Execute(ssn *framework.Session) {
 				job.NodesFitDelta = make(api.NodeResourceMap)
 			}
 
-			predicateNodes, fitErrors := util.PredicateNodes(task, allNodes, predicateFn)
+			predicateNodes, fitErrors := util.PredicateSampledNodes(task, allNodes, predicateFn)
 			if len(predicateNodes) == 0 {
 				job.NodesFitErrors[task.UID] = fitErrors
 				break
@@ -158,7 +164,7 @@ func (alloc *allocateAction) Execute(ssn *framework.Session) {
 
 			node := util.SelectBestNode(nodeScores)
 			// Allocate idle resource to the task.
-			if task.InitResreq.LessEqual(node.Idle) {
+			if reservation.FitIdle(task, node) {
 				glog.V(3).Infof("Binding Task <%v/%v> to node <%v>",
 					task.Namespace, task.Name, node.Name)
 				if err := stmt.Allocate(task, node.Name); err != nil {
@@ -192,6 +198,15 @@ func (alloc *allocateAction) Execute(ssn *framework.Session) {
 		if ssn.JobReady(job) {
 			stmt.Commit()
 		} else {
+			if reservation.StarvingJobs[job.UID] {
+				// Keep the resource allocated to the starving job, so that
+				// other jobs could not starve it again.
+				for _, task := range job.TaskStatusIndex[api.Allocated] {
+					reservation.Reserve(task)
+					glog.V(3).Infof("Reserve <%v> on node <%s> for starving Job <%s/%s>",
+						task.InitResreq, task.NodeName, job.Namespace, job.Name)
+				}
+			}
 			stmt.Discard()
 		}
 		// Added Queue back until no job in Queue.
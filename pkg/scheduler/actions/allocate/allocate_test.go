@@ -31,7 +31,9 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/drf"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
 	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
+	"volcano.sh/volcano/pkg/scheduler/plugins/sla"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
@@ -189,6 +191,7 @@ func TestAllocate(t *testing.T) {
 						Name:               "proportion",
 						EnabledQueueOrder:  &trueValue,
 						EnabledReclaimable: &trueValue,
+						EnabledOverused:    &trueValue,
 					},
 				},
 			},
@@ -210,3 +213,107 @@ func TestAllocate(t *testing.T) {
 		}
 	}
 }
+
+func TestAllocateReserveForStarvingJob(t *testing.T) {
+	framework.RegisterPluginBuilder("sla", sla.New)
+	framework.RegisterPluginBuilder("gang", gang.New)
+	defer framework.CleanupPluginBuilders()
+
+	tests := []struct {
+		name string
+		// waitingTime is the SLA waiting time of the big job.
+		waitingTime string
+		// expectedPending is the count of pending tasks of the small job
+		// after allocate.
+		expectedPending int
+	}{
+		{
+			name:            "resource allocated to starving job is reserved",
+			waitingTime:     "1m",
+			expectedPending: 1,
+		},
+		{
+			name:            "resource is not reserved for job which is not starving",
+			waitingTime:     "24h",
+			expectedPending: 0,
+		},
+	}
+
+	for i, test := range tests {
+		schedulerCache := &cache.SchedulerCache{
+			Nodes:         make(map[string]*api.NodeInfo),
+			Jobs:          make(map[api.JobID]*api.JobInfo),
+			Queues:        make(map[api.QueueID]*api.QueueInfo),
+			Binder:        &util.FakeBinder{Binds: map[string]string{}, Channel: make(chan string, 10)},
+			StatusUpdater: &util.FakeStatusUpdater{},
+			VolumeBinder:  &util.FakeVolumeBinder{},
+
+			Recorder: record.NewFakeRecorder(100),
+		}
+
+		schedulerCache.AddNode(util.BuildNode("n1", util.BuildResourceList("2", "4Gi"), make(map[string]string)))
+		for _, pod := range []*v1.Pod{
+			// The big job could not be ready on n1.
+			util.BuildPod("c1", "big-1", "", v1.PodPending, util.BuildResourceList("1", "1G"), "big", make(map[string]string), make(map[string]string)),
+			util.BuildPod("c1", "big-2", "", v1.PodPending, util.BuildResourceList("1", "1G"), "big", make(map[string]string), make(map[string]string)),
+			util.BuildPod("c1", "big-3", "", v1.PodPending, util.BuildResourceList("1", "1G"), "big", make(map[string]string), make(map[string]string)),
+			util.BuildPod("c1", "small-1", "", v1.PodPending, util.BuildResourceList("1", "1G"), "small", make(map[string]string), make(map[string]string)),
+		} {
+			schedulerCache.AddPod(pod)
+		}
+		for _, pg := range []*kbv1.PodGroup{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "big",
+					Namespace:         "c1",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+					Annotations:       map[string]string{sla.JobWaitingTimeAnnotationKey: test.waitingTime},
+				},
+				Spec:   kbv1.PodGroupSpec{Queue: "c1", MinMember: 3},
+				Status: kbv1.PodGroupStatus{Phase: kbv1.PodGroupInqueue},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "small",
+					Namespace:         "c1",
+					CreationTimestamp: metav1.NewTime(time.Now()),
+				},
+				Spec:   kbv1.PodGroupSpec{Queue: "c1", MinMember: 1},
+				Status: kbv1.PodGroupStatus{Phase: kbv1.PodGroupInqueue},
+			},
+		} {
+			schedulerCache.AddPodGroup(pg)
+		}
+		schedulerCache.AddQueue(&kbv1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1"},
+			Spec:       kbv1.QueueSpec{Weight: 1},
+		})
+
+		trueValue := true
+		ssn := framework.OpenSession(schedulerCache, []conf.Tier{
+			{
+				Plugins: []conf.PluginOption{
+					{
+						Name:               "sla",
+						EnabledJobOrder:    &trueValue,
+						EnabledJobStarving: &trueValue,
+					},
+					{
+						Name:            "gang",
+						EnabledJobReady: &trueValue,
+					},
+				},
+			},
+		})
+
+		New().Execute(ssn)
+
+		small := ssn.Jobs[api.JobID("c1/small")]
+		if pending := len(small.TaskStatusIndex[api.Pending]); pending != test.expectedPending {
+			t.Errorf("case %d (%s): expected %d pending tasks of small job, got %d",
+				i, test.name, test.expectedPending, pending)
+		}
+
+		framework.CloseSession(ssn)
+	}
+}
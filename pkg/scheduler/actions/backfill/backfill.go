@@ -42,6 +42,9 @@ func (alloc *backfillAction) Execute(ssn *framework.Session) {
 	glog.V(3).Infof("Enter Backfill ...")
 	defer glog.V(3).Infof("Leaving Backfill ...")
 
+	// Nodes locked by reserve action are kept for the target job.
+	predicateFn := framework.GetReservation().PredicateFn(ssn.PredicateFn)
+
 	// TODO (k82cn): When backfill, it's also need to balance between Queues.
 	for _, job := range ssn.Jobs {
 		if job.PodGroup.Status.Phase == v1alpha1.PodGroupPending {
@@ -62,7 +65,7 @@ func (alloc *backfillAction) Execute(ssn *framework.Session) {
 				for _, node := range ssn.Nodes {
 					// TODO (k82cn): predicates did not consider pod number for now, there'll
 					// be ping-pong case here.
-					if err := ssn.PredicateFn(task, node); err != nil {
+					if err := predicateFn(task, node); err != nil {
 						glog.V(3).Infof("Predicates failed for task <%s/%s> on node <%s>: %v",
 							task.Namespace, task.Name, node.Name, err)
 						fe.SetNodeError(node.Name, err)
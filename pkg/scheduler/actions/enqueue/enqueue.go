@@ -64,6 +64,11 @@ func (enqueue *enqueueAction) Execute(ssn *framework.Session) {
 		}
 
 		if job.PodGroup.Status.Phase == v1alpha1.PodGroupPending {
+			if !ssn.Queues[job.Queue].Queue.IsOpen() {
+				glog.V(3).Infof("Skip Job <%s/%s> as Queue <%s> is not open",
+					job.Namespace, job.Name, job.Queue)
+				continue
+			}
 			if _, found := jobsMap[job.Queue]; !found {
 				jobsMap[job.Queue] = util.NewPriorityQueue(ssn.JobOrderFn)
 			}
@@ -105,9 +110,17 @@ func (enqueue *enqueueAction) Execute(ssn *framework.Session) {
 			inqueue = true
 		} else {
 			pgResource := api.NewResource(*job.PodGroup.Spec.MinResources)
-			if ssn.JobEnqueueable(job) && pgResource.LessEqual(nodesIdleRes) {
-				nodesIdleRes.Sub(pgResource)
-				inqueue = true
+			if ssn.JobEnqueueable(job) {
+				if pgResource.LessEqual(nodesIdleRes) {
+					nodesIdleRes.Sub(pgResource)
+					inqueue = true
+				} else if ssn.JobStarving(job) {
+					// Starving job is enqueued even if there is not enough idle resource,
+					// so that allocate could reserve resource for it.
+					glog.V(3).Infof("Enqueue starving Job <%s/%s> without enough idle resource",
+						job.Namespace, job.Name)
+					inqueue = true
+				}
 			}
 		}
 
@@ -188,7 +188,10 @@ func preempt(
 
 	allNodes := util.GetNodeList(nodes)
 
-	predicateNodes, _ := util.PredicateNodes(preemptor, allNodes, ssn.PredicateFn)
+	// Nodes locked by reserve action are kept for the target job.
+	predicateFn := framework.GetReservation().PredicateFn(ssn.PredicateFn)
+
+	predicateNodes, _ := util.PredicateSampledNodes(preemptor, allNodes, predicateFn)
 
 	nodeScores := util.PrioritizeNodes(preemptor, predicateNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
 
@@ -89,6 +89,9 @@ func (alloc *reclaimAction) Execute(ssn *framework.Session) {
 		}
 	}
 
+	// Nodes locked by reserve action are kept for the target job.
+	predicateFn := framework.GetReservation().PredicateFn(ssn.PredicateFn)
+
 	for {
 		// If no queues, break
 		if queues.Empty() {
@@ -121,7 +124,7 @@ func (alloc *reclaimAction) Execute(ssn *framework.Session) {
 		assigned := false
 		for _, n := range ssn.Nodes {
 			// If predicates failed, next node.
-			if err := ssn.PredicateFn(task, n); err != nil {
+			if err := predicateFn(task, n); err != nil {
 				continue
 			}
 
@@ -0,0 +1,178 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reserve
+
+import (
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// defaultTimeout is used if the scheduler options are not registered, e.g. in tests.
+const defaultTimeout = 30 * time.Minute
+
+type reserveAction struct {
+	ssn *framework.Session
+}
+
+func New() *reserveAction {
+	return &reserveAction{}
+}
+
+func (reserve *reserveAction) Name() string {
+	return "reserve"
+}
+
+func (reserve *reserveAction) Initialize() {}
+
+// Execute locks nodes for the job selected by TargetJob among the ones which
+// are enqueued but could not be ready, so that other jobs could not be
+// allocated onto them until enough resources are released for the job. The
+// nodes are locked until the job is ready, is gone, or the reservation times
+// out. It should run after allocate, e.g. "enqueue, allocate, reserve, backfill".
+func (reserve *reserveAction) Execute(ssn *framework.Session) {
+	glog.V(3).Infof("Enter Reserve ...")
+	defer glog.V(3).Infof("Leaving Reserve ...")
+
+	reservation := framework.GetReservation()
+	now := time.Now()
+
+	for jobID := range reservation.ExpiredJobs {
+		if _, found := ssn.Jobs[jobID]; !found {
+			delete(reservation.ExpiredJobs, jobID)
+		}
+	}
+
+	if len(reservation.TargetJob) != 0 {
+		job, found := ssn.Jobs[reservation.TargetJob]
+		switch {
+		case !found:
+			glog.V(3).Infof("Release %d nodes locked for Job <%s> which is gone",
+				len(reservation.LockedNodes), reservation.TargetJob)
+			reservation.Release()
+		case ssn.JobReady(job) || job.PodGroup.Status.Phase == v1alpha1.PodGroupRunning:
+			glog.V(3).Infof("Release %d nodes locked for Job <%s/%s> which is ready",
+				len(reservation.LockedNodes), job.Namespace, job.Name)
+			reservation.Release()
+		case now.Sub(reservation.LockTime) >= timeout():
+			glog.V(3).Infof("Release %d nodes locked for Job <%s/%s> as reservation timed out",
+				len(reservation.LockedNodes), job.Namespace, job.Name)
+			reservation.ExpiredJobs[job.UID] = true
+			reservation.Release()
+		default:
+			glog.V(4).Infof("Keep %d nodes locked for Job <%s/%s>",
+				len(reservation.LockedNodes), job.Namespace, job.Name)
+			return
+		}
+	}
+
+	var candidates []*api.JobInfo
+	for _, job := range ssn.Jobs {
+		if reservation.ExpiredJobs[job.UID] {
+			continue
+		}
+		if job.PodGroup.Status.Phase != v1alpha1.PodGroupInqueue {
+			continue
+		}
+		if len(job.TaskStatusIndex[api.Pending]) == 0 || ssn.JobReady(job) {
+			continue
+		}
+		if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
+			continue
+		}
+		candidates = append(candidates, job)
+	}
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	job := ssn.TargetJob(candidates)
+	if job == nil {
+		glog.V(4).Infof("No job is targeted among %d candidates", len(candidates))
+		return
+	}
+
+	nodes := selectNodes(ssn, job)
+	if len(nodes) == 0 {
+		glog.V(3).Infof("Could not find enough nodes to lock for Job <%s/%s>",
+			job.Namespace, job.Name)
+		return
+	}
+
+	glog.V(3).Infof("Lock nodes %v for Job <%s/%s>", nodes, job.Namespace, job.Name)
+	reservation.Lock(job.UID, nodes, now)
+}
+
+func (reserve *reserveAction) UnInitialize() {}
+
+// selectNodes returns the nodes to lock for the pending tasks of job; the
+// feasible nodes with more idle resource go first, until their allocatable
+// resource could fit the tasks. Nil is returned if all the feasible nodes
+// could not fit them.
+func selectNodes(ssn *framework.Session, job *api.JobInfo) []string {
+	request := api.EmptyResource()
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		request.Add(task.InitResreq)
+	}
+
+	var nodes []*api.NodeInfo
+	for _, node := range ssn.Nodes {
+		for _, task := range job.TaskStatusIndex[api.Pending] {
+			if err := ssn.PredicateFn(task, node); err == nil {
+				nodes = append(nodes, node)
+				break
+			}
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		l, r := nodes[i].Idle, nodes[j].Idle
+		if l.MilliCPU != r.MilliCPU {
+			return l.MilliCPU > r.MilliCPU
+		}
+		if l.Memory != r.Memory {
+			return l.Memory > r.Memory
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	capacity := api.EmptyResource()
+	var names []string
+	for _, node := range nodes {
+		capacity.Add(node.Allocatable)
+		names = append(names, node.Name)
+		if request.LessEqual(capacity) {
+			return names
+		}
+	}
+
+	return nil
+}
+
+func timeout() time.Duration {
+	if options.ServerOpts != nil && options.ServerOpts.ReservationTimeout > 0 {
+		return options.ServerOpts.ReservationTimeout
+	}
+	return defaultTimeout
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reserve
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/reservation"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestReserve(t *testing.T) {
+	framework.RegisterPluginBuilder("gang", gang.New)
+	framework.RegisterPluginBuilder("reservation", reservation.New)
+	defer framework.CleanupPluginBuilders()
+
+	framework.ResetReservation()
+	defer framework.ResetReservation()
+
+	schedulerCache := &cache.SchedulerCache{
+		Nodes:         make(map[string]*api.NodeInfo),
+		Jobs:          make(map[api.JobID]*api.JobInfo),
+		Queues:        make(map[api.QueueID]*api.QueueInfo),
+		Binder:        &util.FakeBinder{Binds: map[string]string{}, Channel: make(chan string, 10)},
+		StatusUpdater: &util.FakeStatusUpdater{},
+		VolumeBinder:  &util.FakeVolumeBinder{},
+
+		Recorder: record.NewFakeRecorder(100),
+	}
+
+	for _, node := range []*v1.Node{
+		util.BuildNode("n1", util.BuildResourceList("2", "4Gi"), make(map[string]string)),
+		util.BuildNode("n2", util.BuildResourceList("2", "4Gi"), make(map[string]string)),
+		util.BuildNode("n3", util.BuildResourceList("1", "1Gi"), make(map[string]string)),
+	} {
+		schedulerCache.AddNode(node)
+	}
+
+	for _, pod := range []*v1.Pod{
+		// The running job uses half of n1 and n2.
+		util.BuildPod("c1", "small-1", "n1", v1.PodRunning, util.BuildResourceList("1", "1G"), "small", make(map[string]string), make(map[string]string)),
+		util.BuildPod("c1", "small-2", "n2", v1.PodRunning, util.BuildResourceList("1", "1G"), "small", make(map[string]string), make(map[string]string)),
+		// The big job needs the whole n1 and n2.
+		util.BuildPod("c1", "big-1", "", v1.PodPending, util.BuildResourceList("1", "1G"), "big", make(map[string]string), make(map[string]string)),
+		util.BuildPod("c1", "big-2", "", v1.PodPending, util.BuildResourceList("1", "1G"), "big", make(map[string]string), make(map[string]string)),
+		util.BuildPod("c1", "big-3", "", v1.PodPending, util.BuildResourceList("1", "1G"), "big", make(map[string]string), make(map[string]string)),
+		util.BuildPod("c1", "big-4", "", v1.PodPending, util.BuildResourceList("1", "1G"), "big", make(map[string]string), make(map[string]string)),
+	} {
+		schedulerCache.AddPod(pod)
+	}
+
+	for _, pg := range []*kbv1.PodGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "c1"},
+			Spec:       kbv1.PodGroupSpec{Queue: "c1", MinMember: 2},
+			Status:     kbv1.PodGroupStatus{Phase: kbv1.PodGroupRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "big", Namespace: "c1"},
+			Spec:       kbv1.PodGroupSpec{Queue: "c1", MinMember: 4},
+			Status:     kbv1.PodGroupStatus{Phase: kbv1.PodGroupInqueue},
+		},
+	} {
+		schedulerCache.AddPodGroup(pg)
+	}
+
+	schedulerCache.AddQueue(&kbv1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "c1"},
+		Spec:       kbv1.QueueSpec{Weight: 1},
+	})
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            "gang",
+					EnabledJobReady: &trueValue,
+					EnabledJobValid: &trueValue,
+				},
+				{
+					Name:             "reservation",
+					EnabledTargetJob: &trueValue,
+				},
+			},
+		},
+	}
+
+	reserve := New()
+	r := framework.GetReservation()
+
+	execute := func() {
+		ssn := framework.OpenSession(schedulerCache, tiers)
+		defer framework.CloseSession(ssn)
+		reserve.Execute(ssn)
+	}
+
+	bigJob := api.JobID("c1/big")
+	expectedNodes := map[string]bool{"n1": true, "n2": true}
+
+	// The nodes with more idle resource are locked first, n3 is left.
+	execute()
+	if r.TargetJob != bigJob {
+		t.Fatalf("expected target job %s, got %s", bigJob, r.TargetJob)
+	}
+	if !reflect.DeepEqual(expectedNodes, r.LockedNodes) {
+		t.Errorf("expected locked nodes %v, got %v", expectedNodes, r.LockedNodes)
+	}
+
+	other := &api.TaskInfo{Job: "c1/other"}
+	if r.Allows(other, "n1") || !r.Allows(other, "n3") {
+		t.Errorf("expected task of other job to be allowed on n3 only")
+	}
+	if !r.Allows(&api.TaskInfo{Job: bigJob}, "n1") {
+		t.Errorf("expected task of target job to be allowed on n1")
+	}
+
+	// Actions wrapping their predicates with the reservation skip locked nodes.
+	predicateFn := r.PredicateFn(func(*api.TaskInfo, *api.NodeInfo) error { return nil })
+	if err := predicateFn(other, &api.NodeInfo{Name: "n1"}); err == nil {
+		t.Errorf("expected task of other job to be rejected on n1")
+	}
+
+	// The nodes are kept locked in next session.
+	lockTime := r.LockTime
+	execute()
+	if r.TargetJob != bigJob || !r.LockTime.Equal(lockTime) {
+		t.Errorf("expected reservation for %s to be kept, got %s locked at %v", bigJob, r.TargetJob, r.LockTime)
+	}
+
+	// After timeout, the nodes are released and the job is not targeted again.
+	r.LockTime = time.Now().Add(-2 * defaultTimeout)
+	execute()
+	if r.TargetJob != "" || len(r.LockedNodes) != 0 {
+		t.Errorf("expected reservation to be released, got %s with nodes %v", r.TargetJob, r.LockedNodes)
+	}
+	if !r.ExpiredJobs[bigJob] {
+		t.Errorf("expected job %s to be expired", bigJob)
+	}
+}
@@ -154,6 +154,9 @@ type JobInfo struct {
 
 	// TODO(k82cn): keep backward compatibility, removed it when v1alpha1 finalized.
 	PDB *policyv1.PodDisruptionBudget
+
+	// generation is increased on every change of the job and its tasks
+	generation int64
 }
 
 // NewJobInfo creates a new jobInfo for set of tasks
@@ -182,11 +185,15 @@ func NewJobInfo(uid JobID, tasks ...*TaskInfo) *JobInfo {
 
 // UnsetPodGroup removes podGroup details from a job
 func (ji *JobInfo) UnsetPodGroup() {
+	ji.generation++
+
 	ji.PodGroup = nil
 }
 
 // SetPodGroup sets podGroup details to a job
 func (ji *JobInfo) SetPodGroup(pg *v1alpha1.PodGroup) {
+	ji.generation++
+
 	ji.Name = pg.Name
 	ji.Namespace = pg.Namespace
 	ji.MinAvailable = pg.Spec.MinMember
@@ -198,6 +205,8 @@ func (ji *JobInfo) SetPodGroup(pg *v1alpha1.PodGroup) {
 
 // SetPDB sets PDB to a job
 func (ji *JobInfo) SetPDB(pdb *policyv1.PodDisruptionBudget) {
+	ji.generation++
+
 	ji.Name = pdb.Name
 	ji.MinAvailable = pdb.Spec.MinAvailable.IntVal
 	ji.Namespace = pdb.Namespace
@@ -208,9 +217,17 @@ func (ji *JobInfo) SetPDB(pdb *policyv1.PodDisruptionBudget) {
 
 // UnsetPDB removes PDB info of a job
 func (ji *JobInfo) UnsetPDB() {
+	ji.generation++
+
 	ji.PDB = nil
 }
 
+// Generation returns the generation of jobInfo object, which is increased
+// on every change of the job and its tasks by its methods
+func (ji *JobInfo) Generation() int64 {
+	return ji.generation
+}
+
 // GetTasks gets all tasks with the taskStatus
 func (ji *JobInfo) GetTasks(statuses ...TaskStatus) []*TaskInfo {
 	var res []*TaskInfo
@@ -236,6 +253,8 @@ func (ji *JobInfo) addTaskIndex(ti *TaskInfo) {
 
 // AddTaskInfo is used to add a task to a job
 func (ji *JobInfo) AddTaskInfo(ti *TaskInfo) {
+	ji.generation++
+
 	ji.Tasks[ti.UID] = ti
 	ji.addTaskIndex(ti)
 
@@ -248,6 +267,8 @@ func (ji *JobInfo) AddTaskInfo(ti *TaskInfo) {
 
 // UpdateTaskStatus is used to update task's status in a job
 func (ji *JobInfo) UpdateTaskStatus(task *TaskInfo, status TaskStatus) error {
+	ji.generation++
+
 	if err := validateStatusUpdate(task.Status, status); err != nil {
 		return err
 	}
@@ -274,6 +295,8 @@ func (ji *JobInfo) deleteTaskIndex(ti *TaskInfo) {
 
 // DeleteTaskInfo is used to delete a task from a job
 func (ji *JobInfo) DeleteTaskInfo(ti *TaskInfo) error {
+	ji.generation++
+
 	if task, found := ji.Tasks[ti.UID]; found {
 		ji.TotalRequest.Sub(task.Resreq)
 
@@ -47,6 +47,9 @@ type NodeInfo struct {
 
 	// Used to store custom information
 	Others map[string]interface{}
+
+	// generation is increased on every change of the node
+	generation int64
 }
 
 // NodeState defines the current state of node.
@@ -102,12 +105,20 @@ func (ni *NodeInfo) Clone() *NodeInfo {
 	return res
 }
 
+// Generation returns the generation of nodeInfo object, which is increased
+// on every change by its methods
+func (ni *NodeInfo) Generation() int64 {
+	return ni.generation
+}
+
 // Ready returns whether node is ready for scheduling
 func (ni *NodeInfo) Ready() bool {
 	return ni.State.Phase == Ready
 }
 
 func (ni *NodeInfo) setNodeState(node *v1.Node) {
+	ni.generation++
+
 	// If node is nil, the node is un-initialized in cache
 	if node == nil {
 		ni.State = NodeState{
@@ -175,6 +186,8 @@ func (ni *NodeInfo) allocateIdleResource(ti *TaskInfo) error {
 
 // AddTask is used to add a task in nodeInfo object
 func (ni *NodeInfo) AddTask(task *TaskInfo) error {
+	ni.generation++
+
 	key := PodKey(task.Pod)
 	if _, found := ni.Tasks[key]; found {
 		return fmt.Errorf("task <%v/%v> already on node <%v>",
@@ -210,6 +223,8 @@ func (ni *NodeInfo) AddTask(task *TaskInfo) error {
 
 // RemoveTask used to remove a task from nodeInfo object
 func (ni *NodeInfo) RemoveTask(ti *TaskInfo) error {
+	ni.generation++
+
 	key := PodKey(ti.Pod)
 
 	task, found := ni.Tasks[key]
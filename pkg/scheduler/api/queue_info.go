@@ -32,6 +32,9 @@ type QueueInfo struct {
 
 	Weight int32
 
+	// Parent is the ID of the parent queue, empty for top level queues.
+	Parent QueueID
+
 	Queue *arbcorev1.Queue
 }
 
@@ -42,6 +45,7 @@ func NewQueueInfo(queue *arbcorev1.Queue) *QueueInfo {
 		Name: queue.Name,
 
 		Weight: queue.Spec.Weight,
+		Parent: QueueID(queue.Spec.Parent),
 
 		Queue: queue,
 	}
@@ -53,6 +57,7 @@ func (q *QueueInfo) Clone() *QueueInfo {
 		UID:    q.UID,
 		Name:   q.Name,
 		Weight: q.Weight,
+		Parent: q.Parent,
 		Queue:  q.Queue,
 	}
 }
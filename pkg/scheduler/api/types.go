@@ -117,6 +117,21 @@ type CompareFn func(interface{}, interface{}) int
 // ValidateFn is the func declaration used to check object's status.
 type ValidateFn func(interface{}) bool
 
+// VoteFn is the func declaration used to let plugins vote on object's status.
+type VoteFn func(interface{}) int
+
+// TargetJobFn is the func declaration used to select the job to reserve resources for.
+type TargetJobFn func([]*JobInfo) *JobInfo
+
+const (
+	// Permit means the plugin permits the object.
+	Permit = 1
+	// Abstain means the plugin has no opinion on the object.
+	Abstain = 0
+	// Reject means the plugin rejects the object.
+	Reject = -1
+)
+
 // ValidateResult is struct to which can used to determine the result
 type ValidateResult struct {
 	Pass    bool
@@ -128,18 +143,21 @@ type ValidateResult struct {
 type ValidateExFn func(interface{}) *ValidateResult
 
 // PredicateFn is the func declaration used to predicate node for task.
+// It's called for nodes concurrently, so it must be safe for concurrent use.
 type PredicateFn func(*TaskInfo, *NodeInfo) error
 
 // EvictableFn is the func declaration used to evict tasks.
 type EvictableFn func(*TaskInfo, []*TaskInfo) []*TaskInfo
 
 // NodeOrderFn is the func declaration used to get priority score for a node for a particular task.
+// It's called for nodes concurrently, so it must be safe for concurrent use.
 type NodeOrderFn func(*TaskInfo, *NodeInfo) (float64, error)
 
 // BatchNodeOrderFn is the func declaration used to get priority score for ALL nodes for a particular task.
 type BatchNodeOrderFn func(*TaskInfo, []*NodeInfo) (map[string]float64, error)
 
 // NodeMapFn is the func declaration used to get priority score for a node for a particular task.
+// It's called for nodes concurrently, so it must be safe for concurrent use.
 type NodeMapFn func(*TaskInfo, *NodeInfo) (float64, error)
 
 // NodeReduceFn is the func declaration used to reduce priority score for a node for a particular task.
@@ -13,6 +13,8 @@ const (
 	NodePodNumberExceeded = "node(s) pod number exceeded"
 	// NodeResourceFitFailed means node could not fit the request of pod
 	NodeResourceFitFailed = "node(s) resource fit failed"
+	// NodeLockedForReservation means node is locked for another job by reserve action
+	NodeLockedForReservation = "node(s) locked for reservation"
 
 	// AllNodeUnavailableMsg is the default error message
 	AllNodeUnavailableMsg = "all nodes are unavailable"
@@ -25,6 +25,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/scheduling/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -77,6 +78,8 @@ type SchedulerCache struct {
 	defaultQueue string
 	// schedulerName is the name for kube batch scheduler
 	schedulerName string
+	// partition is the nodes and queues handled by the scheduler.
+	partition *partition
 
 	podInformer      infov1.PodInformer
 	nodeInformer     infov1.NodeInformer
@@ -105,6 +108,15 @@ type SchedulerCache struct {
 
 	errTasks    workqueue.RateLimitingInterface
 	deletedJobs workqueue.RateLimitingInterface
+
+	// snapshotRecorder records the objects in cache when taking snapshot;
+	// nil if disabled.
+	snapshotRecorder *snapshotRecorder
+
+	// nodeSnapshots and jobSnapshots are the clones handed out in last
+	// snapshot, which are reused if not changed since then.
+	nodeSnapshots map[string]*nodeSnapshot
+	jobSnapshots  map[kbapi.JobID]*jobSnapshot
 }
 
 type defaultBinder struct {
@@ -120,6 +132,12 @@ func (db *defaultBinder) Bind(p *v1.Pod, hostname string) error {
 			Name: hostname,
 		},
 	}); err != nil {
+		if apierrors.IsConflict(err) {
+			// The pod may be bound by another scheduler sharing the cluster,
+			// it will be resynced from api server.
+			glog.Warningf("Failed to bind pod <%v/%v> for conflict: %v", p.Namespace, p.Name, err)
+			return err
+		}
 		glog.Errorf("Failed to bind pod <%v/%v>: %#v", p.Namespace, p.Name, err)
 		return err
 	}
@@ -235,6 +253,13 @@ func newSchedulerCache(config *rest.Config, schedulerName string, defaultQueue s
 		schedulerName:   schedulerName,
 	}
 
+	if options.ServerOpts != nil {
+		sc.partition, err = newPartition(options.ServerOpts.NodeSelector, options.ServerOpts.Queues, defaultQueue)
+		if err != nil {
+			panic(fmt.Sprintf("failed init partition, with err: %v", err))
+		}
+	}
+
 	// Prepare event clients.
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartRecordingToSink(&corev1.EventSinkImpl{Interface: eventClient.CoreV1().Events("")})
@@ -271,10 +296,13 @@ func newSchedulerCache(config *rest.Config, schedulerName string, defaultQueue s
 	// create informer for node information
 	sc.nodeInformer = informerFactory.Core().V1().Nodes()
 	sc.nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
-		cache.ResourceEventHandlerFuncs{
-			AddFunc:    sc.AddNode,
-			UpdateFunc: sc.UpdateNode,
-			DeleteFunc: sc.DeleteNode,
+		cache.FilteringResourceEventHandler{
+			FilterFunc: sc.partition.filter,
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    sc.AddNode,
+				UpdateFunc: sc.UpdateNode,
+				DeleteFunc: sc.DeleteNode,
+			},
 		},
 		0,
 	)
@@ -321,18 +349,24 @@ func newSchedulerCache(config *rest.Config, schedulerName string, defaultQueue s
 	kbinformer := kbinfo.NewSharedInformerFactory(sc.kbclient, 0)
 	// create informer for PodGroup information
 	sc.podGroupInformer = kbinformer.Scheduling().V1alpha1().PodGroups()
-	sc.podGroupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    sc.AddPodGroup,
-		UpdateFunc: sc.UpdatePodGroup,
-		DeleteFunc: sc.DeletePodGroup,
+	sc.podGroupInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: sc.partition.filter,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    sc.AddPodGroup,
+			UpdateFunc: sc.UpdatePodGroup,
+			DeleteFunc: sc.DeletePodGroup,
+		},
 	})
 
 	// create informer for Queue information
 	sc.queueInformer = kbinformer.Scheduling().V1alpha1().Queues()
-	sc.queueInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    sc.AddQueue,
-		UpdateFunc: sc.UpdateQueue,
-		DeleteFunc: sc.DeleteQueue,
+	sc.queueInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: sc.partition.filter,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    sc.AddQueue,
+			UpdateFunc: sc.UpdateQueue,
+			DeleteFunc: sc.DeleteQueue,
+		},
 	})
 
 	return sc
@@ -353,6 +387,11 @@ func (sc *SchedulerCache) Run(stopCh <-chan struct{}) {
 		go sc.pcInformer.Informer().Run(stopCh)
 	}
 
+	if len(options.ServerOpts.SnapshotDir) != 0 {
+		sc.snapshotRecorder = newSnapshotRecorder(options.ServerOpts.SnapshotDir,
+			options.ServerOpts.SnapshotPeriod, options.ServerOpts.SnapshotMaxFiles)
+	}
+
 	// Re-sync error tasks.
 	go wait.Until(sc.processResyncTask, 0, stopCh)
 
@@ -591,38 +630,35 @@ func (sc *SchedulerCache) Snapshot() *kbapi.ClusterInfo {
 		Queues: make(map[kbapi.QueueID]*kbapi.QueueInfo),
 	}
 
+	nodeSnapshots := make(map[string]*nodeSnapshot, len(sc.Nodes))
 	for _, value := range sc.Nodes {
 		if !value.Ready() {
 			continue
 		}
 
-		snapshot.Nodes[value.Name] = value.Clone()
+		ns := sc.nodeSnapshots[value.Name].reuse(value)
+		if ns == nil {
+			ns = newNodeSnapshot(value)
+		}
+		nodeSnapshots[value.Name] = ns
+		snapshot.Nodes[value.Name] = ns.clone
 	}
+	sc.nodeSnapshots = nodeSnapshots
 
 	for _, value := range sc.Queues {
 		snapshot.Queues[value.UID] = value.Clone()
 	}
 
+	jobSnapshots := make(map[kbapi.JobID]*jobSnapshot, len(sc.Jobs))
 	var cloneJobLock sync.Mutex
 	var wg sync.WaitGroup
 
 	cloneJob := func(value *api.JobInfo) {
-		if value.PodGroup != nil {
-			value.Priority = sc.defaultPriority
-
-			priName := value.PodGroup.Spec.PriorityClassName
-			if priorityClass, found := sc.PriorityClasses[priName]; found {
-				value.Priority = priorityClass.Value
-			}
-
-			glog.V(4).Infof("The priority of job <%s/%s> is <%s/%d>",
-				value.Namespace, value.Name, priName, value.Priority)
-		}
-
-		clonedJob := value.Clone()
+		js := newJobSnapshot(value)
 
 		cloneJobLock.Lock()
-		snapshot.Jobs[value.UID] = clonedJob
+		jobSnapshots[value.UID] = js
+		snapshot.Jobs[value.UID] = js.clone
 		cloneJobLock.Unlock()
 		wg.Done()
 	}
@@ -642,14 +678,39 @@ func (sc *SchedulerCache) Snapshot() *kbapi.ClusterInfo {
 			continue
 		}
 
+		if value.PodGroup != nil {
+			value.Priority = sc.defaultPriority
+
+			priName := value.PodGroup.Spec.PriorityClassName
+			if priorityClass, found := sc.PriorityClasses[priName]; found {
+				value.Priority = priorityClass.Value
+			}
+
+			glog.V(4).Infof("The priority of job <%s/%s> is <%s/%d>",
+				value.Namespace, value.Name, priName, value.Priority)
+		}
+
+		if js := sc.jobSnapshots[value.UID].reuse(value); js != nil {
+			cloneJobLock.Lock()
+			jobSnapshots[value.UID] = js
+			snapshot.Jobs[value.UID] = js.clone
+			cloneJobLock.Unlock()
+			continue
+		}
+
 		wg.Add(1)
 		go cloneJob(value)
 	}
 	wg.Wait()
+	sc.jobSnapshots = jobSnapshots
 
 	glog.V(3).Infof("There are <%d> Jobs, <%d> Queues and <%d> Nodes in total for scheduling.",
 		len(snapshot.Jobs), len(snapshot.Queues), len(snapshot.Nodes))
 
+	if now := time.Now(); sc.snapshotRecorder != nil && sc.snapshotRecorder.due(now) {
+		go sc.snapshotRecorder.record(now, sc.snapshotObjects())
+	}
+
 	return snapshot
 }
 
@@ -29,13 +29,34 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/api"
 )
 
+// exportedFieldsEqual compares the exported fields of two struct pointers,
+// e.g. ignoring the generations of NodeInfo and JobInfo.
+func exportedFieldsEqual(l, r interface{}) bool {
+	lv, rv := reflect.ValueOf(l), reflect.ValueOf(r)
+	if lv.IsNil() || rv.IsNil() {
+		return lv.IsNil() == rv.IsNil()
+	}
+
+	lv, rv = lv.Elem(), rv.Elem()
+	for i := 0; i < lv.NumField(); i++ {
+		if len(lv.Type().Field(i).PkgPath) != 0 {
+			continue
+		}
+		if !reflect.DeepEqual(lv.Field(i).Interface(), rv.Field(i).Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func nodesEqual(l, r map[string]*api.NodeInfo) bool {
 	if len(l) != len(r) {
 		return false
 	}
 
 	for k, n := range l {
-		if !reflect.DeepEqual(n, r[k]) {
+		if !exportedFieldsEqual(n, r[k]) {
 			return false
 		}
 	}
@@ -49,7 +70,7 @@ func jobsEqual(l, r map[api.JobID]*api.JobInfo) bool {
 	}
 
 	for k, p := range l {
-		if !reflect.DeepEqual(p, r[k]) {
+		if !exportedFieldsEqual(p, r[k]) {
 			return false
 		}
 	}
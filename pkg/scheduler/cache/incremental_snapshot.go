@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	kbapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// The session changes the NodeInfo and JobInfo in snapshot, so a clone could
+// only be handed out again if neither the object in cache nor the clone was
+// changed since last snapshot, which is told by their generations.
+
+// nodeSnapshot is the clone of a NodeInfo in cache handed out in snapshot.
+type nodeSnapshot struct {
+	source           *kbapi.NodeInfo
+	sourceGeneration int64
+
+	clone           *kbapi.NodeInfo
+	cloneGeneration int64
+}
+
+func newNodeSnapshot(node *kbapi.NodeInfo) *nodeSnapshot {
+	clone := node.Clone()
+
+	return &nodeSnapshot{
+		source:           node,
+		sourceGeneration: node.Generation(),
+		clone:            clone,
+		cloneGeneration:  clone.Generation(),
+	}
+}
+
+// reuse returns the snapshot itself if it's still up to date with node,
+// otherwise nil.
+func (ns *nodeSnapshot) reuse(node *kbapi.NodeInfo) *nodeSnapshot {
+	if ns == nil || ns.source != node ||
+		ns.sourceGeneration != node.Generation() ||
+		ns.cloneGeneration != ns.clone.Generation() {
+		return nil
+	}
+
+	return ns
+}
+
+// jobSnapshot is the clone of a JobInfo in cache handed out in snapshot.
+type jobSnapshot struct {
+	source           *kbapi.JobInfo
+	sourceGeneration int64
+
+	clone           *kbapi.JobInfo
+	cloneGeneration int64
+}
+
+func newJobSnapshot(job *kbapi.JobInfo) *jobSnapshot {
+	clone := job.Clone()
+
+	return &jobSnapshot{
+		source:           job,
+		sourceGeneration: job.Generation(),
+		clone:            clone,
+		cloneGeneration:  clone.Generation(),
+	}
+}
+
+// reuse returns the snapshot itself if the job and its tasks are not changed,
+// otherwise nil. The fields set by the session directly, e.g. the status of
+// PodGroup and fit errors, are reset as in a new clone.
+func (js *jobSnapshot) reuse(job *kbapi.JobInfo) *jobSnapshot {
+	if js == nil || js.source != job ||
+		js.sourceGeneration != job.Generation() ||
+		js.cloneGeneration != js.clone.Generation() {
+		return nil
+	}
+
+	clone := js.clone
+	clone.Queue = job.Queue
+	clone.Priority = job.Priority
+	clone.PodGroup = job.PodGroup.DeepCopy()
+	clone.PDB = job.PDB
+	clone.JobFitErrors = ""
+	clone.NodesFitDelta = make(kbapi.NodeResourceMap)
+	clone.NodesFitErrors = make(map[kbapi.TaskID]*kbapi.FitErrors)
+
+	return js
+}
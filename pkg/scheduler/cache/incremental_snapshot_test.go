@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/scheduling/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func TestIncrementalSnapshot(t *testing.T) {
+	cache := &SchedulerCache{
+		Nodes:           make(map[string]*api.NodeInfo),
+		Jobs:            make(map[api.JobID]*api.JobInfo),
+		Queues:          make(map[api.QueueID]*api.QueueInfo),
+		PriorityClasses: make(map[string]*v1beta1.PriorityClass),
+	}
+
+	pod1 := buildPod("c1", "p1", "", v1.PodPending, buildResourceList("1000m", "1G"), nil, nil)
+	pod1.Annotations = map[string]string{kbv1.GroupNameAnnotationKey: "pg1"}
+	pod2 := buildPod("c1", "p2", "n1", v1.PodRunning, buildResourceList("1000m", "1G"), nil, nil)
+	pod2.Annotations = map[string]string{kbv1.GroupNameAnnotationKey: "pg1"}
+
+	cache.AddNode(buildNode("n1", buildResourceList("4000m", "10G")))
+	cache.AddNode(buildNode("n2", buildResourceList("4000m", "10G")))
+	cache.AddQueue(&kbv1.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1"}})
+	cache.AddPodGroup(&kbv1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "pg1", Namespace: "c1"},
+		Spec:       kbv1.PodGroupSpec{Queue: "q1"},
+	})
+	cache.AddPod(pod1)
+
+	jobID := api.JobID("c1/pg1")
+
+	first := cache.Snapshot()
+	second := cache.Snapshot()
+	if first.Nodes["n1"] != second.Nodes["n1"] || first.Jobs[jobID] != second.Jobs[jobID] {
+		t.Errorf("Expected unchanged node and job to be reused")
+	}
+
+	// Changes by session are not handed out again.
+	job := second.Jobs[jobID]
+	job.JobFitErrors = "0/2 nodes are available"
+	job.PodGroup.Status.Phase = kbv1.PodGroupRunning
+	for _, task := range job.Tasks {
+		if err := second.Nodes["n2"].AddTask(task); err != nil {
+			t.Fatalf("Failed to add task to node: %v", err)
+		}
+	}
+
+	third := cache.Snapshot()
+	if third.Nodes["n2"] == second.Nodes["n2"] {
+		t.Errorf("Expected node changed by session to be cloned again")
+	}
+	if len(third.Nodes["n2"].Tasks) != 0 {
+		t.Errorf("Expected no task on node n2, got %d", len(third.Nodes["n2"].Tasks))
+	}
+	if third.Nodes["n1"] != second.Nodes["n1"] {
+		t.Errorf("Expected unchanged node n1 to be reused")
+	}
+	if third.Jobs[jobID] != job {
+		t.Errorf("Expected job with unchanged tasks to be reused")
+	}
+	if len(job.JobFitErrors) != 0 || job.PodGroup.Status.Phase == kbv1.PodGroupRunning {
+		t.Errorf("Expected the fields set by session to be reset")
+	}
+
+	// Changes in cache are taken into the snapshot.
+	cache.AddPod(pod2)
+
+	fourth := cache.Snapshot()
+	if fourth.Nodes["n1"] == third.Nodes["n1"] || fourth.Jobs[jobID] == third.Jobs[jobID] {
+		t.Errorf("Expected node and job changed in cache to be cloned again")
+	}
+	if len(fourth.Nodes["n1"].Tasks) != 1 || len(fourth.Jobs[jobID].Tasks) != 2 {
+		t.Errorf("Expected new pod in snapshot, got %d tasks on node and %d tasks in job",
+			len(fourth.Nodes["n1"].Tasks), len(fourth.Jobs[jobID].Tasks))
+	}
+}
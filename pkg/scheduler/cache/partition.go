@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+)
+
+// partition is the nodes and queues handled by a scheduler, so that several
+// schedulers could share a cluster. The jobs are scheduled by the scheduler
+// whose partition has their queue, onto the nodes of that partition. The
+// partitions of schedulers must not overlap.
+type partition struct {
+	nodeSelector labels.Selector
+	// queues is the names of queues in partition, all queues if empty.
+	queues       map[string]bool
+	defaultQueue string
+}
+
+// newPartition returns the partition of nodes matching nodeSelector and the
+// queues; all nodes or queues are in the partition if not set.
+func newPartition(nodeSelector string, queues []string, defaultQueue string) (*partition, error) {
+	selector, err := labels.Parse(nodeSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &partition{
+		nodeSelector: selector,
+		queues:       map[string]bool{},
+		defaultQueue: defaultQueue,
+	}
+	for _, queue := range queues {
+		p.queues[queue] = true
+	}
+
+	return p, nil
+}
+
+func (p *partition) hasNode(node *v1.Node) bool {
+	return p == nil || p.nodeSelector.Matches(labels.Set(node.Labels))
+}
+
+func (p *partition) hasQueue(queue string) bool {
+	if p == nil || len(p.queues) == 0 {
+		return true
+	}
+	if len(queue) == 0 {
+		queue = p.defaultQueue
+	}
+	return p.queues[queue]
+}
+
+// filter returns whether the object is in partition; only Node, Queue and
+// PodGroup are filtered.
+func (p *partition) filter(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	switch t := obj.(type) {
+	case *v1.Node:
+		return p.hasNode(t)
+	case *v1alpha1.Queue:
+		return p.hasQueue(t.Name)
+	case *v1alpha1.PodGroup:
+		return p.hasQueue(t.Spec.Queue)
+	default:
+		return true
+	}
+}
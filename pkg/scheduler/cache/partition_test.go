@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+)
+
+func TestPartitionFilter(t *testing.T) {
+	gpuNode := buildNode("n1", buildResourceList("2000m", "10G"))
+	gpuNode.Labels = map[string]string{"accelerator": "gpu"}
+	cpuNode := buildNode("n2", buildResourceList("2000m", "10G"))
+
+	buildQueue := func(name string) *kbv1.Queue {
+		return &kbv1.Queue{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	buildPodGroup := func(queue string) *kbv1.PodGroup {
+		return &kbv1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "pg", Namespace: "c1"},
+			Spec:       kbv1.PodGroupSpec{Queue: queue},
+		}
+	}
+
+	gpuPartition, err := newPartition("accelerator=gpu", []string{"training"}, "default")
+	if err != nil {
+		t.Fatalf("Failed to create partition: %v", err)
+	}
+	defaultPartition, err := newPartition("accelerator!=gpu", []string{"default"}, "default")
+	if err != nil {
+		t.Fatalf("Failed to create partition: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		partition *partition
+		obj       interface{}
+		expected  bool
+	}{
+		{
+			name:      "node matching selector",
+			partition: gpuPartition,
+			obj:       gpuNode,
+			expected:  true,
+		},
+		{
+			name:      "node not matching selector",
+			partition: gpuPartition,
+			obj:       cpuNode,
+			expected:  false,
+		},
+		{
+			name:      "deleted node not matching selector",
+			partition: gpuPartition,
+			obj:       cache.DeletedFinalStateUnknown{Key: "n2", Obj: cpuNode},
+			expected:  false,
+		},
+		{
+			name:      "node not matching negative selector",
+			partition: defaultPartition,
+			obj:       gpuNode,
+			expected:  false,
+		},
+		{
+			name:      "queue in partition",
+			partition: gpuPartition,
+			obj:       buildQueue("training"),
+			expected:  true,
+		},
+		{
+			name:      "queue not in partition",
+			partition: gpuPartition,
+			obj:       buildQueue("default"),
+			expected:  false,
+		},
+		{
+			name:      "podgroup without queue in default partition",
+			partition: defaultPartition,
+			obj:       buildPodGroup(""),
+			expected:  true,
+		},
+		{
+			name:      "podgroup without queue in other partition",
+			partition: gpuPartition,
+			obj:       buildPodGroup(""),
+			expected:  false,
+		},
+		{
+			name:     "no partition",
+			obj:      cpuNode,
+			expected: true,
+		},
+		{
+			name:      "pod is not filtered",
+			partition: gpuPartition,
+			obj:       &v1.Pod{},
+			expected:  true,
+		},
+	}
+
+	for i, test := range tests {
+		if got := test.partition.filter(test.obj); got != test.expected {
+			t.Errorf("case %d (%s): expected %v, got %v", i, test.name, test.expected, got)
+		}
+	}
+
+	if _, err := newPartition("accelerator in (gpu", nil, "default"); err == nil {
+		t.Errorf("expected error for invalid node selector")
+	}
+}
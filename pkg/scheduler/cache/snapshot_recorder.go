@@ -0,0 +1,251 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+
+	kbschema "volcano.sh/volcano/pkg/client/clientset/versioned/scheme"
+	kbapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	snapshotFilePrefix = "snapshot-"
+	snapshotFileSuffix = ".json"
+	// snapshotTimeFormat keeps the file names in chronological order.
+	snapshotTimeFormat = "20060102-150405.000"
+	// snapshotTaskStatusAnnotationKey records the status of the task in the
+	// pod, which could not be derived from the pod, e.g. Binding.
+	snapshotTaskStatusAnnotationKey = "scheduling.volcano.sh/snapshot-task-status"
+)
+
+var snapshotScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(kubescheme.AddToScheme(snapshotScheme))
+	utilruntime.Must(kbschema.AddToScheme(snapshotScheme))
+}
+
+// snapshotRecorder periodically writes the objects in cache into a directory,
+// at most maxFiles snapshots are kept. A snapshot is a List of Nodes, Pods,
+// PodGroups, Queues and PriorityClasses, which could be replayed by
+// vc-scheduler-sim to reproduce the scheduling cycle.
+type snapshotRecorder struct {
+	dir      string
+	period   time.Duration
+	maxFiles int
+
+	lastRecordTime time.Time
+}
+
+func newSnapshotRecorder(dir string, period time.Duration, maxFiles int) *snapshotRecorder {
+	return &snapshotRecorder{
+		dir:      dir,
+		period:   period,
+		maxFiles: maxFiles,
+	}
+}
+
+// due returns true and resets the timer if it's time to record a snapshot.
+func (sr *snapshotRecorder) due(now time.Time) bool {
+	if now.Sub(sr.lastRecordTime) < sr.period {
+		return false
+	}
+	sr.lastRecordTime = now
+	return true
+}
+
+// record writes the objects into a new snapshot file and removes the oldest
+// ones beyond maxFiles.
+func (sr *snapshotRecorder) record(now time.Time, objs []runtime.Object) {
+	data, err := encodeSnapshot(objs)
+	if err != nil {
+		glog.Errorf("Failed to encode snapshot of scheduler cache: %v", err)
+		return
+	}
+
+	if err := os.MkdirAll(sr.dir, 0755); err != nil {
+		glog.Errorf("Failed to create snapshot directory %s: %v", sr.dir, err)
+		return
+	}
+
+	file := filepath.Join(sr.dir, snapshotFilePrefix+now.UTC().Format(snapshotTimeFormat)+snapshotFileSuffix)
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		glog.Errorf("Failed to write snapshot of scheduler cache into %s: %v", file, err)
+		return
+	}
+	glog.V(3).Infof("Recorded snapshot of scheduler cache into %s", file)
+
+	sr.rotate()
+}
+
+func (sr *snapshotRecorder) rotate() {
+	files, err := ioutil.ReadDir(sr.dir)
+	if err != nil {
+		glog.Errorf("Failed to list snapshot directory %s: %v", sr.dir, err)
+		return
+	}
+
+	var snapshots []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasPrefix(f.Name(), snapshotFilePrefix) && strings.HasSuffix(f.Name(), snapshotFileSuffix) {
+			snapshots = append(snapshots, f.Name())
+		}
+	}
+	sort.Strings(snapshots)
+
+	for i := 0; i < len(snapshots)-sr.maxFiles; i++ {
+		if err := os.Remove(filepath.Join(sr.dir, snapshots[i])); err != nil {
+			glog.Errorf("Failed to remove snapshot %s: %v", snapshots[i], err)
+		}
+	}
+}
+
+// snapshotObjects deep copies the objects in cache, the NodeName and the task
+// status of pods are set as the cache sees them, e.g. for the tasks being bound.
+// Assumes that lock is already acquired.
+func (sc *SchedulerCache) snapshotObjects() []runtime.Object {
+	var objs []runtime.Object
+
+	for _, node := range sc.Nodes {
+		if node.Node != nil {
+			objs = append(objs, node.Node.DeepCopy())
+		}
+	}
+
+	for _, queue := range sc.Queues {
+		if queue.Queue != nil {
+			objs = append(objs, queue.Queue.DeepCopy())
+		}
+	}
+
+	for _, pc := range sc.PriorityClasses {
+		objs = append(objs, pc.DeepCopy())
+	}
+
+	pods := map[kbapi.TaskID]bool{}
+	addTask := func(task *kbapi.TaskInfo) {
+		if task.Pod == nil || pods[task.UID] {
+			return
+		}
+		pods[task.UID] = true
+
+		pod := task.Pod.DeepCopy()
+		if len(task.NodeName) != 0 {
+			pod.Spec.NodeName = task.NodeName
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[snapshotTaskStatusAnnotationKey] = task.Status.String()
+		objs = append(objs, pod)
+	}
+
+	for _, job := range sc.Jobs {
+		// Shadow PodGroups are created by cache from the pods.
+		if job.PodGroup != nil && !shadowPodGroup(job.PodGroup) {
+			objs = append(objs, job.PodGroup.DeepCopy())
+		}
+		for _, task := range job.Tasks {
+			addTask(task)
+		}
+	}
+
+	// Pods not managed by this scheduler are only found on nodes.
+	for _, node := range sc.Nodes {
+		for _, task := range node.Tasks {
+			addTask(task)
+		}
+	}
+
+	return objs
+}
+
+// AddSnapshotPod adds the pod replayed from a snapshot into cache, restoring
+// the status of its task recorded in the snapshot.
+func (sc *SchedulerCache) AddSnapshotPod(pod *v1.Pod) error {
+	sc.Mutex.Lock()
+	defer sc.Mutex.Unlock()
+
+	task := kbapi.NewTaskInfo(pod)
+	if value, found := pod.Annotations[snapshotTaskStatusAnnotationKey]; found {
+		status, err := parseTaskStatus(value)
+		if err != nil {
+			return fmt.Errorf("failed to restore status of pod <%s/%s>: %v", pod.Namespace, pod.Name, err)
+		}
+		task.Status = status
+	}
+
+	return sc.addTask(task)
+}
+
+func parseTaskStatus(value string) (kbapi.TaskStatus, error) {
+	for _, status := range []kbapi.TaskStatus{
+		kbapi.Pending, kbapi.Allocated, kbapi.Pipelined, kbapi.Binding, kbapi.Bound,
+		kbapi.Running, kbapi.Releasing, kbapi.Succeeded, kbapi.Failed, kbapi.Unknown,
+	} {
+		if status.String() == value {
+			return status, nil
+		}
+	}
+
+	return kbapi.Unknown, fmt.Errorf("unknown task status %q", value)
+}
+
+// encodeSnapshot encodes the objects into a v1.List in JSON.
+func encodeSnapshot(objs []runtime.Object) ([]byte, error) {
+	list := &v1.List{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "List",
+		},
+	}
+
+	for _, obj := range objs {
+		gvks, _, err := snapshotScheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, err
+		}
+		if len(gvks) == 0 {
+			return nil, fmt.Errorf("no kind is registered for %T", obj)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: raw})
+	}
+
+	return json.MarshalIndent(list, "", "  ")
+}
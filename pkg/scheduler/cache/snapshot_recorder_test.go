@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/scheduling/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func TestSnapshotRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := &SchedulerCache{
+		Nodes:           make(map[string]*api.NodeInfo),
+		Jobs:            make(map[api.JobID]*api.JobInfo),
+		Queues:          make(map[api.QueueID]*api.QueueInfo),
+		PriorityClasses: make(map[string]*v1beta1.PriorityClass),
+	}
+
+	pod1 := buildPod("c1", "p1", "", v1.PodPending, buildResourceList("1000m", "1G"), nil, nil)
+	pod1.Annotations = map[string]string{kbv1.GroupNameAnnotationKey: "pg1"}
+	pod2 := buildPod("c1", "p2", "n1", v1.PodRunning, buildResourceList("1000m", "1G"), nil, nil)
+
+	cache.AddNode(buildNode("n1", buildResourceList("2000m", "10G")))
+	cache.AddQueue(&kbv1.Queue{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	cache.AddPodGroup(&kbv1.PodGroup{ObjectMeta: metav1.ObjectMeta{Name: "pg1", Namespace: "c1"}})
+	cache.AddPod(pod1)
+	cache.AddPod(pod2)
+
+	recorder := newSnapshotRecorder(dir, time.Minute, 2)
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if !recorder.due(now) {
+			t.Errorf("Expected snapshot to be recorded at %v", now)
+		}
+		if recorder.due(now.Add(time.Second)) {
+			t.Errorf("Expected no snapshot to be recorded within period at %v", now)
+		}
+		recorder.record(now, cache.snapshotObjects())
+		now = now.Add(time.Minute)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, snapshotFilePrefix+"*"))
+	if err != nil {
+		t.Fatalf("Failed to list snapshots: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 snapshots kept, got %v", files)
+	}
+
+	data, err := ioutil.ReadFile(files[1])
+	if err != nil {
+		t.Fatalf("Failed to read snapshot: %v", err)
+	}
+	obj, _, err := serializer.NewCodecFactory(snapshotScheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to decode snapshot: %v", err)
+	}
+	list, ok := obj.(*v1.List)
+	if !ok {
+		t.Fatalf("Expected snapshot to be a List, got %T", obj)
+	}
+	// Node, Queue, PodGroup and two Pods.
+	if len(list.Items) != 5 {
+		t.Errorf("Expected 5 objects in snapshot, got %d", len(list.Items))
+	}
+}
+
+func TestSnapshotTaskStatus(t *testing.T) {
+	newCache := func() *SchedulerCache {
+		cache := &SchedulerCache{
+			Nodes:           make(map[string]*api.NodeInfo),
+			Jobs:            make(map[api.JobID]*api.JobInfo),
+			Queues:          make(map[api.QueueID]*api.QueueInfo),
+			PriorityClasses: make(map[string]*v1beta1.PriorityClass),
+		}
+		cache.AddNode(buildNode("n1", buildResourceList("2000m", "10G")))
+		cache.AddQueue(&kbv1.Queue{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+		cache.AddPodGroup(&kbv1.PodGroup{ObjectMeta: metav1.ObjectMeta{Name: "pg1", Namespace: "c1"}})
+		return cache
+	}
+
+	pod := buildPod("c1", "p1", "", v1.PodPending, buildResourceList("1000m", "1G"), nil, nil)
+	pod.Annotations = map[string]string{kbv1.GroupNameAnnotationKey: "pg1"}
+
+	// The task is being bound to n1, which is only known by cache.
+	cache := newCache()
+	cache.AddPod(pod)
+	job := cache.Jobs["c1/pg1"]
+	for _, task := range job.Tasks {
+		task.NodeName = "n1"
+		if err := job.UpdateTaskStatus(task, api.Binding); err != nil {
+			t.Fatalf("Failed to update task status: %v", err)
+		}
+		if err := cache.Nodes["n1"].AddTask(task); err != nil {
+			t.Fatalf("Failed to add task to node: %v", err)
+		}
+	}
+
+	replayed := newCache()
+	for _, obj := range cache.snapshotObjects() {
+		if pod, ok := obj.(*v1.Pod); ok {
+			if err := replayed.AddSnapshotPod(pod); err != nil {
+				t.Fatalf("Failed to add pod in snapshot: %v", err)
+			}
+		}
+	}
+
+	if binding := len(replayed.Jobs["c1/pg1"].TaskStatusIndex[api.Binding]); binding != 1 {
+		t.Errorf("Expected 1 Binding task after replay, got %d", binding)
+	}
+	if node := replayed.Nodes["n1"]; len(node.Tasks) != 1 || node.Used.MilliCPU != 1000 {
+		t.Errorf("Expected the Binding task to use resource on n1, got %d tasks using <%v>", len(node.Tasks), node.Used)
+	}
+}
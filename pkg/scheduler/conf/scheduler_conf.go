@@ -51,6 +51,23 @@ type PluginOption struct {
 	EnabledPredicate *bool `yaml:"enablePredicate"`
 	// EnabledNodeOrder defines whether NodeOrderFn is enabled
 	EnabledNodeOrder *bool `yaml:"enableNodeOrder"`
+	// EnabledBatchNodeOrder defines whether batchNodeOrderFn is enabled,
+	// defaults to EnabledNodeOrder
+	EnabledBatchNodeOrder *bool `yaml:"enableBatchNodeOrder"`
+	// EnabledNodeMap defines whether nodeMapFn is enabled, defaults to EnabledNodeOrder
+	EnabledNodeMap *bool `yaml:"enableNodeMap"`
+	// EnabledNodeReduce defines whether nodeReduceFn is enabled, defaults to EnabledNodeOrder
+	EnabledNodeReduce *bool `yaml:"enableNodeReduce"`
+	// EnabledOverused defines whether overusedFn is enabled
+	EnabledOverused *bool `yaml:"enableOverused"`
+	// EnabledJobValid defines whether jobValidFn is enabled
+	EnabledJobValid *bool `yaml:"enableJobValid"`
+	// EnabledJobEnqueueable defines whether jobEnqueueableFn is enabled
+	EnabledJobEnqueueable *bool `yaml:"enableJobEnqueueable"`
+	// EnabledJobStarving defines whether jobStarvingFn is enabled
+	EnabledJobStarving *bool `yaml:"enableJobStarving"`
+	// EnabledTargetJob defines whether targetJobFn is enabled
+	EnabledTargetJob *bool `yaml:"enableTargetJob"`
 	// Arguments defines the different arguments that can be given to different plugins
 	Arguments map[string]string `yaml:"arguments"`
 }
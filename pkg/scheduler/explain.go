@@ -0,0 +1,54 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// ExplainPath is the path of the endpoint explaining why a job is scheduled
+// or not in the last session, e.g. /explain/<namespace>/<podgroup>.
+const ExplainPath = "/explain/"
+
+// ExplainHandler serves the explanation of the job in JSON.
+func ExplainHandler(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, ExplainPath), "/"), "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		http.Error(w, fmt.Sprintf("expected path %s<namespace>/<podgroup>", ExplainPath), http.StatusBadRequest)
+		return
+	}
+
+	jobID := api.JobID(fmt.Sprintf("%s/%s", parts[0], parts[1]))
+	explanation, found := framework.GetJobExplanation(jobID)
+	if !found {
+		http.Error(w, fmt.Sprintf("job %s was not handled in last session", jobID), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(explanation); err != nil {
+		glog.Errorf("Failed to write explanation of job %s: %v", jobID, err)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestExplainHandler(t *testing.T) {
+	framework.RegisterPluginBuilder("gang", gang.New)
+	defer framework.CleanupPluginBuilders()
+
+	schedulerCache := &cache.SchedulerCache{
+		Nodes:         make(map[string]*api.NodeInfo),
+		Jobs:          make(map[api.JobID]*api.JobInfo),
+		Queues:        make(map[api.QueueID]*api.QueueInfo),
+		Binder:        &util.FakeBinder{Binds: map[string]string{}, Channel: make(chan string, 1)},
+		StatusUpdater: &util.FakeStatusUpdater{},
+		VolumeBinder:  &util.FakeVolumeBinder{},
+
+		Recorder: record.NewFakeRecorder(100),
+	}
+	schedulerCache.AddQueue(&kbv1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "q1"},
+		Spec:       kbv1.QueueSpec{Weight: 1},
+	})
+	schedulerCache.AddPodGroup(&kbv1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "pg1", Namespace: "c1"},
+		Spec:       kbv1.PodGroupSpec{Queue: "q1", MinMember: 2},
+	})
+	schedulerCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, util.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)))
+
+	trueValue := true
+	ssn := framework.OpenSession(schedulerCache, []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            "gang",
+					EnabledJobValid: &trueValue,
+				},
+			},
+		},
+	})
+	for _, job := range ssn.Jobs {
+		ssn.JobValid(job)
+	}
+	framework.CloseSession(ssn)
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedPlugin string
+	}{
+		{
+			path:           "/explain/c1/pg1",
+			expectedStatus: http.StatusOK,
+			expectedPlugin: "gang",
+		},
+		{
+			path:           "/explain/c1/pg2",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			path:           "/explain/c1",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for i, test := range tests {
+		recorder := httptest.NewRecorder()
+		ExplainHandler(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if recorder.Code != test.expectedStatus {
+			t.Errorf("case %d (%s): expected status %d, got %d", i, test.path, test.expectedStatus, recorder.Code)
+			continue
+		}
+		if test.expectedStatus != http.StatusOK {
+			continue
+		}
+
+		explanation := &framework.JobExplanation{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), explanation); err != nil {
+			t.Errorf("case %d (%s): failed to decode explanation: %v", i, test.path, err)
+			continue
+		}
+		if explanation.Invalid == nil || explanation.Invalid.Plugin != test.expectedPlugin {
+			t.Errorf("case %d (%s): expected job invalidated by %s, got %v", i, test.path, test.expectedPlugin, explanation.Invalid)
+		}
+		if explanation.Invalid != nil && explanation.Invalid.Reason != kbv1.NotEnoughPodsReason {
+			t.Errorf("case %d (%s): expected reason %s, got %s", i, test.path, kbv1.NotEnoughPodsReason, explanation.Invalid.Reason)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// JobExplanation explains the decisions made for a job in the last session
+// which handled it.
+type JobExplanation struct {
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	SessionUID types.UID `json:"sessionUID"`
+	Time       time.Time `json:"time"`
+
+	// Invalid is set if the job was rejected by JobValid.
+	Invalid *PluginDecision `json:"invalid,omitempty"`
+	// Enqueue is set if the enqueue action voted on the job.
+	Enqueue *EnqueueDecision `json:"enqueue,omitempty"`
+	// PreemptVetoes and ReclaimVetoes are the plugins which allowed no
+	// victims for the tasks of the job.
+	PreemptVetoes []PluginDecision `json:"preemptVetoes,omitempty"`
+	ReclaimVetoes []PluginDecision `json:"reclaimVetoes,omitempty"`
+
+	// JobFitErrors and NodesFitErrors are why the tasks could not be
+	// allocated, NodesFitErrors is keyed by task name.
+	JobFitErrors   string            `json:"jobFitErrors,omitempty"`
+	NodesFitErrors map[string]string `json:"nodesFitErrors,omitempty"`
+}
+
+// PluginDecision is a decision made by a plugin.
+type PluginDecision struct {
+	Plugin  string `json:"plugin"`
+	Task    string `json:"task,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// EnqueueDecision is the result of voting on the job by plugins; Plugin is
+// the one that rejected or permitted the job, empty if all abstained.
+type EnqueueDecision struct {
+	Enqueueable bool   `json:"enqueueable"`
+	Plugin      string `json:"plugin,omitempty"`
+}
+
+// jobExplanations are the explanations of the jobs in a session.
+type jobExplanations struct {
+	sync.Mutex
+	jobs map[api.JobID]*JobExplanation
+}
+
+func newJobExplanations() *jobExplanations {
+	return &jobExplanations{
+		jobs: map[api.JobID]*JobExplanation{},
+	}
+}
+
+// explanations are the ones published by the last session.
+var explanations = struct {
+	sync.RWMutex
+	jobs map[api.JobID]*JobExplanation
+}{
+	jobs: map[api.JobID]*JobExplanation{},
+}
+
+// GetJobExplanation returns the explanation of the job in the last session
+// which handled it.
+func GetJobExplanation(jobID api.JobID) (*JobExplanation, bool) {
+	explanations.RLock()
+	defer explanations.RUnlock()
+
+	e, found := explanations.jobs[jobID]
+	return e, found
+}
+
+// explain returns the explanation of the job in the session, creating it if
+// not found.
+func (ssn *Session) explain(job *api.JobInfo) *JobExplanation {
+	ssn.explanations.Lock()
+	defer ssn.explanations.Unlock()
+
+	e, found := ssn.explanations.jobs[job.UID]
+	if !found {
+		e = &JobExplanation{
+			Namespace:  job.Namespace,
+			Name:       job.Name,
+			SessionUID: ssn.UID,
+			Time:       time.Now(),
+		}
+		ssn.explanations.jobs[job.UID] = e
+	}
+	return e
+}
+
+// publishExplanations makes the explanations of the session visible to
+// GetJobExplanation, replacing the ones of the jobs in previous sessions.
+func (ssn *Session) publishExplanations() {
+	for _, job := range ssn.Jobs {
+		e := ssn.explain(job)
+		e.JobFitErrors = job.JobFitErrors
+		for taskID, fitErrors := range job.NodesFitErrors {
+			if e.NodesFitErrors == nil {
+				e.NodesFitErrors = map[string]string{}
+			}
+			name := string(taskID)
+			if task, found := job.Tasks[taskID]; found {
+				name = task.Name
+			}
+			e.NodesFitErrors[name] = fitErrors.Error()
+		}
+	}
+
+	explanations.Lock()
+	defer explanations.Unlock()
+
+	explanations.jobs = ssn.explanations.jobs
+}
+
+// explainVeto records that the plugin allowed no victims for the task to
+// reclaim or preempt.
+func (ssn *Session) explainVeto(task *api.TaskInfo, plugin string, reclaim bool) {
+	job, found := ssn.Jobs[task.Job]
+	if !found {
+		return
+	}
+	e := ssn.explain(job)
+
+	ssn.explanations.Lock()
+	defer ssn.explanations.Unlock()
+
+	vetoes := &e.PreemptVetoes
+	if reclaim {
+		vetoes = &e.ReclaimVetoes
+	}
+	for _, v := range *vetoes {
+		if v.Plugin == plugin && v.Task == task.Name {
+			return
+		}
+	}
+	*vetoes = append(*vetoes, PluginDecision{Plugin: plugin, Task: task.Name})
+}
+
+// explainInvalid records that the plugin rejected the job in JobValid.
+func (ssn *Session) explainInvalid(obj interface{}, plugin string, vr *api.ValidateResult) {
+	job, ok := obj.(*api.JobInfo)
+	if !ok {
+		return
+	}
+	e := ssn.explain(job)
+
+	ssn.explanations.Lock()
+	defer ssn.explanations.Unlock()
+
+	e.Invalid = &PluginDecision{Plugin: plugin, Reason: vr.Reason, Message: vr.Message}
+}
+
+// explainEnqueue records the result of voting on the job in JobEnqueueable.
+func (ssn *Session) explainEnqueue(obj interface{}, plugin string, enqueueable bool) {
+	job, ok := obj.(*api.JobInfo)
+	if !ok {
+		return
+	}
+	e := ssn.explain(job)
+
+	ssn.explanations.Lock()
+	defer ssn.explanations.Unlock()
+
+	e.Enqueue = &EnqueueDecision{Enqueueable: enqueueable, Plugin: plugin}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// pluginBuilderSymbol is the symbol of PluginBuilder exported by a
+	// custom plugin, e.g. `func New(arguments framework.Arguments) framework.Plugin`.
+	pluginBuilderSymbol = "New"
+	// actionBuilderSymbol is the symbol of Action builder exported by a
+	// custom plugin, e.g. `func NewAction() framework.Action`.
+	actionBuilderSymbol = "NewAction"
+
+	pluginFileSuffix = ".so"
+)
+
+// symbolLookup looks up the exported symbol in a custom plugin.
+type symbolLookup func(symbol string) (interface{}, error)
+
+// LoadCustomPlugins loads the Go plugins (.so files) in pluginsDir, which must
+// be built with the same Go version and volcano sources as the scheduler.
+// The Plugin exported by a file is registered with the file name without
+// suffix, e.g. `sitefit.so` is referenced as `sitefit` in tiers; the Action is
+// registered with its Name(). Names of built-in plugins and actions could not
+// be reused.
+//
+// Go plugins are only supported on Linux and macOS by the scheduler built with
+// cgo, e.g. `make vc-scheduler`; the released images are built without cgo,
+// so they fail to start if pluginsDir is set.
+func LoadCustomPlugins(pluginsDir string) error {
+	if len(pluginsDir) == 0 {
+		return nil
+	}
+
+	files, err := ioutil.ReadDir(pluginsDir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != pluginFileSuffix {
+			continue
+		}
+
+		path := filepath.Join(pluginsDir, f.Name())
+		lookup, err := openPlugin(path)
+		if err == nil {
+			err = registerCustomPlugin(path, strings.TrimSuffix(f.Name(), pluginFileSuffix), lookup)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load custom plugin %s: %v", path, err)
+		}
+	}
+
+	return nil
+}
+
+// registerCustomPlugin registers the Plugin and Action exported by the custom
+// plugin at path.
+func registerCustomPlugin(path, name string, lookup symbolLookup) error {
+	var pluginBuilder PluginBuilder
+	var action Action
+
+	if sym, err := lookup(pluginBuilderSymbol); err == nil {
+		builder, ok := sym.(func(Arguments) Plugin)
+		if !ok {
+			return fmt.Errorf("symbol %s is %T, expected func(framework.Arguments) framework.Plugin",
+				pluginBuilderSymbol, sym)
+		}
+		if _, found := GetPluginBuilder(name); found {
+			return fmt.Errorf("plugin <%s> is already registered", name)
+		}
+		pluginBuilder = builder
+	}
+
+	if sym, err := lookup(actionBuilderSymbol); err == nil {
+		builder, ok := sym.(func() Action)
+		if !ok {
+			return fmt.Errorf("symbol %s is %T, expected func() framework.Action",
+				actionBuilderSymbol, sym)
+		}
+		action = builder()
+		if _, found := GetAction(action.Name()); found {
+			return fmt.Errorf("action <%s> is already registered", action.Name())
+		}
+	}
+
+	if pluginBuilder == nil && action == nil {
+		return fmt.Errorf("neither %s nor %s is exported", pluginBuilderSymbol, actionBuilderSymbol)
+	}
+
+	if pluginBuilder != nil {
+		RegisterPluginBuilder(name, pluginBuilder)
+		glog.Infof("Registered custom plugin <%s> from %s", name, path)
+	}
+	if action != nil {
+		RegisterAction(action)
+		glog.Infof("Registered custom action <%s> from %s", action.Name(), path)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCustomPlugins(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		expectErr bool
+	}{
+		{
+			name: "no plugin files",
+			files: map[string]string{
+				"README.md": "custom plugins",
+			},
+		},
+		{
+			name: "invalid plugin file",
+			files: map[string]string{
+				"broken.so": "not a shared object",
+			},
+			expectErr: true,
+		},
+	}
+
+	for i, test := range tests {
+		dir, err := ioutil.TempDir("", "custom-plugins")
+		if err != nil {
+			t.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		for name, content := range test.files {
+			if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", name, err)
+			}
+		}
+
+		err = LoadCustomPlugins(dir)
+		if test.expectErr != (err != nil) {
+			t.Errorf("case %d (%s): expected error %v, got %v", i, test.name, test.expectErr, err)
+		}
+	}
+
+	if err := LoadCustomPlugins(""); err != nil {
+		t.Errorf("expected no error for empty plugins dir, got %v", err)
+	}
+}
+
+type fakeAction struct {
+	name string
+}
+
+func (fa *fakeAction) Name() string         { return fa.name }
+func (fa *fakeAction) Initialize()          {}
+func (fa *fakeAction) Execute(ssn *Session) {}
+func (fa *fakeAction) UnInitialize()        {}
+
+type fakePlugin struct{}
+
+func (fp *fakePlugin) Name() string                { return "fake" }
+func (fp *fakePlugin) OnSessionOpen(ssn *Session)  {}
+func (fp *fakePlugin) OnSessionClose(ssn *Session) {}
+
+func TestRegisterCustomPlugin(t *testing.T) {
+	newPlugin := func(Arguments) Plugin { return &fakePlugin{} }
+	newAction := func(name string) func() Action {
+		return func() Action { return &fakeAction{name: name} }
+	}
+
+	tests := []struct {
+		name            string
+		pluginName      string
+		symbols         map[string]interface{}
+		expectErr       bool
+		expectedPlugins []string
+		expectedActions []string
+	}{
+		{
+			name:            "plugin exported",
+			symbols:         map[string]interface{}{pluginBuilderSymbol: newPlugin},
+			expectedPlugins: []string{"custom"},
+		},
+		{
+			name:            "action exported",
+			symbols:         map[string]interface{}{actionBuilderSymbol: newAction("custom-action")},
+			expectedActions: []string{"custom-action"},
+		},
+		{
+			name: "plugin and action exported",
+			symbols: map[string]interface{}{
+				pluginBuilderSymbol: newPlugin,
+				actionBuilderSymbol: newAction("custom-action"),
+			},
+			expectedPlugins: []string{"custom"},
+			expectedActions: []string{"custom-action"},
+		},
+		{
+			name:      "nothing exported",
+			symbols:   map[string]interface{}{},
+			expectErr: true,
+		},
+		{
+			name:      "plugin builder of wrong type",
+			symbols:   map[string]interface{}{pluginBuilderSymbol: func() Plugin { return &fakePlugin{} }},
+			expectErr: true,
+		},
+		{
+			name:      "action builder of wrong type",
+			symbols:   map[string]interface{}{actionBuilderSymbol: &fakeAction{name: "custom-action"}},
+			expectErr: true,
+		},
+		{
+			name:       "plugin name clashes with built-in plugin",
+			pluginName: "builtin",
+			symbols:    map[string]interface{}{pluginBuilderSymbol: newPlugin},
+			expectErr:  true,
+		},
+		{
+			name: "action name clashes with built-in action",
+			symbols: map[string]interface{}{
+				pluginBuilderSymbol: newPlugin,
+				actionBuilderSymbol: newAction("builtin-action"),
+			},
+			expectErr: true,
+		},
+	}
+
+	for i, test := range tests {
+		RegisterPluginBuilder("builtin", newPlugin)
+		RegisterAction(&fakeAction{name: "builtin-action"})
+
+		pluginName := test.pluginName
+		if len(pluginName) == 0 {
+			pluginName = "custom"
+		}
+		lookup := func(symbol string) (interface{}, error) {
+			if sym, found := test.symbols[symbol]; found {
+				return sym, nil
+			}
+			return nil, fmt.Errorf("symbol %s not found", symbol)
+		}
+
+		err := registerCustomPlugin("custom.so", pluginName, lookup)
+		if test.expectErr != (err != nil) {
+			t.Errorf("case %d (%s): expected error %v, got %v", i, test.name, test.expectErr, err)
+		}
+
+		for _, name := range test.expectedPlugins {
+			if _, found := GetPluginBuilder(name); !found {
+				t.Errorf("case %d (%s): expected plugin <%s> registered", i, test.name, name)
+			}
+		}
+		for _, name := range test.expectedActions {
+			if _, found := GetAction(name); !found {
+				t.Errorf("case %d (%s): expected action <%s> registered", i, test.name, name)
+			}
+		}
+		// Nothing is registered if the custom plugin is rejected.
+		if test.expectErr {
+			if _, found := GetPluginBuilder("custom"); found {
+				t.Errorf("case %d (%s): expected plugin <custom> not registered", i, test.name)
+			}
+			if _, found := GetAction("custom-action"); found {
+				t.Errorf("case %d (%s): expected action <custom-action> not registered", i, test.name)
+			}
+		}
+
+		CleanupPluginBuilders()
+		delete(actionMap, "builtin-action")
+		delete(actionMap, "custom-action")
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"time"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// Reservation keeps resources for jobs which could not be ready against
+// other jobs. The nodes locked for a target job are kept across sessions:
+// tasks of other jobs are not allocated onto them, so that the resources
+// released on them are kept until the target job could be ready; they are
+// updated by reserve action, and are only read by other actions. The idle
+// resource reserved for starving jobs is only kept in current session: it is
+// updated by allocate action and cleared when a session is opened.
+type Reservation struct {
+	// TargetJob is the job which the nodes are locked for, empty if none.
+	TargetJob api.JobID
+	// LockedNodes are the names of the locked nodes.
+	LockedNodes map[string]bool
+	// LockTime is when the nodes were locked.
+	LockTime time.Time
+	// ExpiredJobs are the jobs whose reservation timed out, they are not
+	// targeted again.
+	ExpiredJobs map[api.JobID]bool
+
+	// StarvingJobs are the starving jobs in current session, the reserved
+	// idle resource is kept for them.
+	StarvingJobs map[api.JobID]bool
+	// Reserved is the idle resource of each node kept for starving jobs in
+	// current session.
+	Reserved map[string]*api.Resource
+}
+
+func newReservation() *Reservation {
+	return &Reservation{
+		LockedNodes: map[string]bool{},
+		ExpiredJobs: map[api.JobID]bool{},
+
+		StarvingJobs: map[api.JobID]bool{},
+		Reserved:     map[string]*api.Resource{},
+	}
+}
+
+var reservation = newReservation()
+
+// GetReservation returns the reservation shared by sessions.
+func GetReservation() *Reservation {
+	return reservation
+}
+
+// ResetReservation releases the locked nodes and forgets the expired jobs.
+func ResetReservation() {
+	reservation = newReservation()
+}
+
+// Lock locks the nodes for the job, replacing the previous reservation.
+func (r *Reservation) Lock(job api.JobID, nodes []string, now time.Time) {
+	r.TargetJob = job
+	r.LockedNodes = make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		r.LockedNodes[node] = true
+	}
+	r.LockTime = now
+}
+
+// Release unlocks the nodes.
+func (r *Reservation) Release() {
+	r.TargetJob = ""
+	r.LockedNodes = map[string]bool{}
+	r.LockTime = time.Time{}
+}
+
+// Allows returns whether the task could be allocated onto the node, i.e. the
+// node is not locked for another job.
+func (r *Reservation) Allows(task *api.TaskInfo, node string) bool {
+	return !r.LockedNodes[node] || task.Job == r.TargetJob
+}
+
+// PredicateFn wraps the predicate function, the nodes locked for other jobs
+// are rejected before it is called.
+func (r *Reservation) PredicateFn(fn api.PredicateFn) api.PredicateFn {
+	return func(task *api.TaskInfo, node *api.NodeInfo) error {
+		if !r.Allows(task, node.Name) {
+			return api.NewFitError(task, node, api.NodeLockedForReservation)
+		}
+		return fn(task, node)
+	}
+}
+
+// Reserve keeps the resource of the task on its node for starving jobs in
+// current session.
+func (r *Reservation) Reserve(task *api.TaskInfo) {
+	if _, found := r.Reserved[task.NodeName]; !found {
+		r.Reserved[task.NodeName] = api.EmptyResource()
+	}
+	r.Reserved[task.NodeName].Add(task.InitResreq)
+}
+
+// FitIdle returns whether the idle resource of the node could fit the task;
+// the resource reserved for starving jobs is only available to them.
+func (r *Reservation) FitIdle(task *api.TaskInfo, node *api.NodeInfo) bool {
+	req := task.InitResreq
+	if res, found := r.Reserved[node.Name]; found && !r.StarvingJobs[task.Job] {
+		req = req.Clone().Add(res)
+	}
+	return req.LessEqual(node.Idle)
+}
+
+// resetSession forgets the resource reserved in previous session.
+func (r *Reservation) resetSession() {
+	r.StarvingJobs = map[api.JobID]bool{}
+	r.Reserved = map[string]*api.Resource{}
+}
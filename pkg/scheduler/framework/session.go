@@ -64,6 +64,11 @@ type Session struct {
 	jobPipelinedFns   map[string]api.ValidateFn
 	jobValidFns       map[string]api.ValidateExFn
 	jobEnqueueableFns map[string]api.ValidateFn
+	jobEnqueueVoteFns map[string]api.VoteFn
+	jobStarvingFns    map[string]api.ValidateFn
+	targetJobFns      map[string]api.TargetJobFn
+
+	explanations *jobExplanations
 }
 
 func openSession(cache cache.Cache) *Session {
@@ -93,8 +98,15 @@ func openSession(cache cache.Cache) *Session {
 		jobPipelinedFns:   map[string]api.ValidateFn{},
 		jobValidFns:       map[string]api.ValidateExFn{},
 		jobEnqueueableFns: map[string]api.ValidateFn{},
+		jobEnqueueVoteFns: map[string]api.VoteFn{},
+		jobStarvingFns:    map[string]api.ValidateFn{},
+		targetJobFns:      map[string]api.TargetJobFn{},
+
+		explanations: newJobExplanations(),
 	}
 
+	reservation.resetSession()
+
 	snapshot := cache.Snapshot()
 
 	ssn.Jobs = snapshot.Jobs
@@ -134,6 +146,8 @@ func openSession(cache cache.Cache) *Session {
 }
 
 func closeSession(ssn *Session) {
+	ssn.publishExplanations()
+
 	ju := newJobUpdater(ssn)
 	ju.UpdateAll()
 
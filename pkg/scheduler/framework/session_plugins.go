@@ -96,6 +96,21 @@ func (ssn *Session) AddJobEnqueueableFn(name string, fn api.ValidateFn) {
 	ssn.jobEnqueueableFns[name] = fn
 }
 
+// AddJobEnqueueVoteFn add jobenqueuevote function
+func (ssn *Session) AddJobEnqueueVoteFn(name string, fn api.VoteFn) {
+	ssn.jobEnqueueVoteFns[name] = fn
+}
+
+// AddJobStarvingFn add jobstarving function
+func (ssn *Session) AddJobStarvingFn(name string, fn api.ValidateFn) {
+	ssn.jobStarvingFns[name] = fn
+}
+
+// AddTargetJobFn add targetjob function
+func (ssn *Session) AddTargetJobFn(name string, fn api.TargetJobFn) {
+	ssn.targetJobFns[name] = fn
+}
+
 // Reclaimable invoke reclaimable function of the plugins
 func (ssn *Session) Reclaimable(reclaimer *api.TaskInfo, reclaimees []*api.TaskInfo) []*api.TaskInfo {
 	var victims []*api.TaskInfo
@@ -111,6 +126,9 @@ func (ssn *Session) Reclaimable(reclaimer *api.TaskInfo, reclaimees []*api.TaskI
 				continue
 			}
 			candidates := rf(reclaimer, reclaimees)
+			if len(candidates) == 0 && len(reclaimees) != 0 {
+				ssn.explainVeto(reclaimer, plugin.Name, true)
+			}
 			if !init {
 				victims = candidates
 				init = true
@@ -154,6 +172,9 @@ func (ssn *Session) Preemptable(preemptor *api.TaskInfo, preemptees []*api.TaskI
 				continue
 			}
 			candidates := pf(preemptor, preemptees)
+			if len(candidates) == 0 && len(preemptees) != 0 {
+				ssn.explainVeto(preemptor, plugin.Name, false)
+			}
 			if !init {
 				victims = candidates
 				init = true
@@ -185,6 +206,9 @@ func (ssn *Session) Preemptable(preemptor *api.TaskInfo, preemptees []*api.TaskI
 func (ssn *Session) Overused(queue *api.QueueInfo) bool {
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
+			if !isEnabled(plugin.EnabledOverused) {
+				continue
+			}
 			of, found := ssn.overusedFns[plugin.Name]
 			if !found {
 				continue
@@ -244,12 +268,16 @@ func (ssn *Session) JobPipelined(obj interface{}) bool {
 func (ssn *Session) JobValid(obj interface{}) *api.ValidateResult {
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
+			if !isEnabled(plugin.EnabledJobValid) {
+				continue
+			}
 			jrf, found := ssn.jobValidFns[plugin.Name]
 			if !found {
 				continue
 			}
 
 			if vr := jrf(obj); vr != nil && !vr.Pass {
+				ssn.explainInvalid(obj, plugin.Name, vr)
 				return vr
 			}
 
@@ -259,24 +287,95 @@ func (ssn *Session) JobValid(obj interface{}) *api.ValidateResult {
 	return nil
 }
 
-// JobEnqueueable invoke jobEnqueueableFns function of the plugins
+// JobEnqueueable invoke jobEnqueueableFns and jobEnqueueVoteFns function of
+// the plugins: the job is rejected if any plugin rejects it, unless the job is
+// starving; a Permit vote in a tier skips the plugins of the next tiers.
 func (ssn *Session) JobEnqueueable(obj interface{}) bool {
+	starving := ssn.JobStarving(obj)
+
 	for _, tier := range ssn.Tiers {
+		var permittedBy string
 		for _, plugin := range tier.Plugins {
-			fn, found := ssn.jobEnqueueableFns[plugin.Name]
-			if !found {
+			if !isEnabled(plugin.EnabledJobEnqueueable) {
 				continue
 			}
 
-			if res := fn(obj); !res {
-				return res
+			reject := false
+			if fn, found := ssn.jobEnqueueableFns[plugin.Name]; found && !fn(obj) {
+				reject = true
 			}
+			if fn, found := ssn.jobEnqueueVoteFns[plugin.Name]; found {
+				switch fn(obj) {
+				case api.Reject:
+					reject = true
+				case api.Permit:
+					if len(permittedBy) == 0 {
+						permittedBy = plugin.Name
+					}
+				}
+			}
+
+			// Starving job is enqueued even if plugins reject it.
+			if reject && !starving {
+				ssn.explainEnqueue(obj, plugin.Name, false)
+				return false
+			}
+		}
+		// Plugins in this tier permitted the job and none rejected it,
+		// so do not check the next tier.
+		if len(permittedBy) != 0 {
+			ssn.explainEnqueue(obj, permittedBy, true)
+			return true
 		}
 	}
 
+	ssn.explainEnqueue(obj, "", true)
 	return true
 }
 
+// JobStarving invoke jobStarving function of the plugins
+func (ssn *Session) JobStarving(obj interface{}) bool {
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if !isEnabled(plugin.EnabledJobStarving) {
+				continue
+			}
+			fn, found := ssn.jobStarvingFns[plugin.Name]
+			if !found {
+				continue
+			}
+
+			if fn(obj) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// TargetJob invoke targetjob function of the plugins, the job selected by
+// the first plugin is returned, nil if none selected.
+func (ssn *Session) TargetJob(jobs []*api.JobInfo) *api.JobInfo {
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if !isEnabled(plugin.EnabledTargetJob) {
+				continue
+			}
+			fn, found := ssn.targetJobFns[plugin.Name]
+			if !found {
+				continue
+			}
+
+			if job := fn(jobs); job != nil {
+				return job
+			}
+		}
+	}
+
+	return nil
+}
+
 // JobOrderFn invoke joborder function of the plugins
 func (ssn *Session) JobOrderFn(l, r interface{}) bool {
 	for _, tier := range ssn.Tiers {
@@ -416,7 +515,7 @@ func (ssn *Session) BatchNodeOrderFn(task *api.TaskInfo, nodes []*api.NodeInfo)
 	priorityScore := make(map[string]float64, len(nodes))
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
-			if !isEnabled(plugin.EnabledNodeOrder) {
+			if !isEnabled(plugin.EnabledBatchNodeOrder) {
 				continue
 			}
 			pfn, found := ssn.batchNodeOrderFns[plugin.Name]
@@ -445,17 +544,14 @@ func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[
 	var priorityScore float64
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
-			if !isEnabled(plugin.EnabledNodeOrder) {
-				continue
-			}
-			if pfn, found := ssn.nodeOrderFns[plugin.Name]; found {
+			if pfn, found := ssn.nodeOrderFns[plugin.Name]; found && isEnabled(plugin.EnabledNodeOrder) {
 				score, err := pfn(task, node)
 				if err != nil {
 					return nodeScoreMap, priorityScore, err
 				}
 				priorityScore = priorityScore + score
 			}
-			if pfn, found := ssn.nodeMapFns[plugin.Name]; found {
+			if pfn, found := ssn.nodeMapFns[plugin.Name]; found && isEnabled(plugin.EnabledNodeMap) {
 				score, err := pfn(task, node)
 				if err != nil {
 					return nodeScoreMap, priorityScore, err
@@ -473,7 +569,7 @@ func (ssn *Session) NodeOrderReduceFn(task *api.TaskInfo, pluginNodeScoreMap map
 	nodeScoreMap := map[string]float64{}
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
-			if !isEnabled(plugin.EnabledNodeOrder) {
+			if !isEnabled(plugin.EnabledNodeReduce) {
 				continue
 			}
 			pfn, found := ssn.nodeReduceFns[plugin.Name]
@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+)
+
+func TestJobEnqueueable(t *testing.T) {
+	validate := func(result bool) api.ValidateFn {
+		return func(interface{}) bool { return result }
+	}
+	vote := func(result int) api.VoteFn {
+		return func(interface{}) int { return result }
+	}
+
+	tests := []struct {
+		name string
+		// tiers are the names of the plugins in each tier.
+		tiers      [][]string
+		enqueueFns map[string]api.ValidateFn
+		voteFns    map[string]api.VoteFn
+		starving   bool
+		expected   bool
+		expectedBy string
+	}{
+		{
+			name:     "all plugins abstain",
+			tiers:    [][]string{{"a"}, {"b"}},
+			voteFns:  map[string]api.VoteFn{"a": vote(api.Abstain), "b": vote(api.Abstain)},
+			expected: true,
+		},
+		{
+			name:       "validate function in lower tier rejects",
+			tiers:      [][]string{{"a"}, {"b"}},
+			enqueueFns: map[string]api.ValidateFn{"a": validate(true), "b": validate(false)},
+			expected:   false,
+			expectedBy: "b",
+		},
+		{
+			name:       "permit in same tier does not override reject",
+			tiers:      [][]string{{"a", "b"}},
+			enqueueFns: map[string]api.ValidateFn{"b": validate(false)},
+			voteFns:    map[string]api.VoteFn{"a": vote(api.Permit)},
+			expected:   false,
+			expectedBy: "b",
+		},
+		{
+			name:       "permit skips lower tiers",
+			tiers:      [][]string{{"a"}, {"b"}},
+			enqueueFns: map[string]api.ValidateFn{"b": validate(false)},
+			voteFns:    map[string]api.VoteFn{"a": vote(api.Permit)},
+			expected:   true,
+			expectedBy: "a",
+		},
+		{
+			name:       "starving job overrides reject in same tier",
+			tiers:      [][]string{{"a", "b"}},
+			enqueueFns: map[string]api.ValidateFn{"b": validate(false)},
+			voteFns:    map[string]api.VoteFn{"a": vote(api.Permit)},
+			starving:   true,
+			expected:   true,
+			expectedBy: "a",
+		},
+		{
+			name:     "starving job overrides reject vote",
+			tiers:    [][]string{{"a"}},
+			voteFns:  map[string]api.VoteFn{"a": vote(api.Reject)},
+			starving: true,
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		trueValue := true
+		ssn := &Session{
+			jobEnqueueableFns: map[string]api.ValidateFn{},
+			jobEnqueueVoteFns: map[string]api.VoteFn{},
+			jobStarvingFns:    map[string]api.ValidateFn{},
+			explanations:      newJobExplanations(),
+		}
+		for _, names := range test.tiers {
+			var tier conf.Tier
+			for _, name := range names {
+				tier.Plugins = append(tier.Plugins, conf.PluginOption{
+					Name:                  name,
+					EnabledJobEnqueueable: &trueValue,
+					EnabledJobStarving:    &trueValue,
+				})
+			}
+			ssn.Tiers = append(ssn.Tiers, tier)
+		}
+		for name, fn := range test.enqueueFns {
+			ssn.AddJobEnqueueableFn(name, fn)
+		}
+		for name, fn := range test.voteFns {
+			ssn.AddJobEnqueueVoteFn(name, fn)
+		}
+		starving := test.starving
+		ssn.AddJobStarvingFn(test.tiers[0][0], func(interface{}) bool { return starving })
+
+		job := &api.JobInfo{UID: "job1", Namespace: "ns", Name: "job1"}
+		if enqueueable := ssn.JobEnqueueable(job); enqueueable != test.expected {
+			t.Errorf("case %q: expected enqueueable %t, got %t", test.name, test.expected, enqueueable)
+		}
+
+		decision := ssn.explanations.jobs[job.UID].Enqueue
+		if decision == nil || decision.Plugin != test.expectedBy {
+			t.Errorf("case %q: expected decision by %q, got %+v", test.name, test.expectedBy, decision)
+		}
+	}
+}
@@ -71,6 +71,14 @@ var (
 		},
 	)
 
+	schedulerConfVersion = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "scheduler_conf_version",
+			Help:      "Version of the scheduler configuration in use, increased every time the configuration is reloaded",
+		},
+	)
+
 	scheduleAttempts = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Subsystem: VolcanoNamespace,
@@ -140,6 +148,11 @@ func UpdateTaskScheduleDuration(duration time.Duration) {
 	taskSchedulingLatency.Observe(DurationInMicroseconds(duration))
 }
 
+// UpdateSchedulerConfVersion records version of the scheduler configuration in use
+func UpdateSchedulerConfVersion(version int) {
+	schedulerConfVersion.Set(float64(version))
+}
+
 // UpdatePodScheduleStatus update pod schedule decision, could be Success, Failure, Error
 func UpdatePodScheduleStatus(label string, count int) {
 	scheduleAttempts.WithLabelValues(label).Add(float64(count))
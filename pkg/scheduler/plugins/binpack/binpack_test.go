@@ -0,0 +1,198 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binpack
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestCalculateWeight(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments framework.Arguments
+		expected  priorityWeight
+	}{
+		{
+			name:      "default weights",
+			arguments: framework.Arguments{},
+			expected: priorityWeight{
+				BinPackingWeight:    1,
+				BinPackingCPU:       1,
+				BinPackingMemory:    1,
+				BinPackingResources: map[v1.ResourceName]int{},
+			},
+		},
+		{
+			name: "negative weights are reset to 1",
+			arguments: framework.Arguments{
+				BinpackWeight: "-10",
+				BinpackCPU:    "-2",
+				BinpackMemory: "3",
+			},
+			expected: priorityWeight{
+				BinPackingWeight:    1,
+				BinPackingCPU:       1,
+				BinPackingMemory:    3,
+				BinPackingResources: map[v1.ResourceName]int{},
+			},
+		},
+		{
+			name: "extended resources",
+			arguments: framework.Arguments{
+				BinpackWeight:    "0",
+				BinpackResources: "nvidia.com/gpu, example.com/foo,",
+				BinpackResourcesPrefix + "nvidia.com/gpu":  "2",
+				BinpackResourcesPrefix + "example.com/foo": "-3",
+			},
+			expected: priorityWeight{
+				BinPackingWeight: 0,
+				BinPackingCPU:    1,
+				BinPackingMemory: 1,
+				BinPackingResources: map[v1.ResourceName]int{
+					"nvidia.com/gpu":  2,
+					"example.com/foo": 1,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		if weight := calculateWeight(test.arguments); !reflect.DeepEqual(weight, test.expected) {
+			t.Errorf("case %q: expected weight %v, got %v", test.name, test.expected.String(), weight.String())
+		}
+	}
+}
+
+func TestBinPackingScore(t *testing.T) {
+	node := api.NewNodeInfo(util.BuildNode("n1", util.BuildResourceListWithGPU("4", "8Gi", "4"), make(map[string]string)))
+	buildTask := func(req v1.ResourceList) *api.TaskInfo {
+		return api.NewTaskInfo(util.BuildPod("c1", "p1", "", v1.PodPending, req, "pg1", make(map[string]string), make(map[string]string)))
+	}
+	weight := func(binpack, cpu, memory int, resources map[v1.ResourceName]int) priorityWeight {
+		return priorityWeight{
+			BinPackingWeight:    binpack,
+			BinPackingCPU:       cpu,
+			BinPackingMemory:    memory,
+			BinPackingResources: resources,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		task     *api.TaskInfo
+		weight   priorityWeight
+		expected float64
+	}{
+		{
+			name:     "score is normalized by weight sum",
+			task:     buildTask(util.BuildResourceList("2", "4Gi")),
+			weight:   weight(1, 1, 3, nil),
+			expected: 5,
+		},
+		{
+			name:     "binpack weight scales score",
+			task:     buildTask(util.BuildResourceList("2", "4Gi")),
+			weight:   weight(2, 1, 1, nil),
+			expected: 10,
+		},
+		{
+			name:     "resource with zero weight is skipped",
+			task:     buildTask(util.BuildResourceList("4", "2Gi")),
+			weight:   weight(1, 0, 1, nil),
+			expected: 2.5,
+		},
+		{
+			name:     "resource request over capacity scores zero",
+			task:     buildTask(util.BuildResourceList("8", "4Gi")),
+			weight:   weight(1, 1, 1, nil),
+			expected: 2.5,
+		},
+		{
+			name:     "extended resource with weight",
+			task:     buildTask(util.BuildResourceListWithGPU("2", "4Gi", "1")),
+			weight:   weight(1, 1, 1, map[v1.ResourceName]int{"nvidia.com/gpu": 2}),
+			expected: 3.75,
+		},
+		{
+			name:     "extended resource without weight is ignored",
+			task:     buildTask(util.BuildResourceListWithGPU("2", "4Gi", "1")),
+			weight:   weight(1, 1, 1, nil),
+			expected: 5,
+		},
+	}
+
+	for _, test := range tests {
+		if score := binPackingScore(test.task, node, test.weight); score != test.expected {
+			t.Errorf("case %q: expected score %v, got %v", test.name, test.expected, score)
+		}
+	}
+}
+
+func TestResourceBinPackingScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested float64
+		capacity  float64
+		used      float64
+		weight    int
+		expected  float64
+	}{
+		{
+			name:      "used and requested resource",
+			requested: 1,
+			capacity:  4,
+			used:      1,
+			weight:    2,
+			expected:  1,
+		},
+		{
+			name:      "request over capacity",
+			requested: 4,
+			capacity:  4,
+			used:      1,
+			weight:    1,
+			expected:  0,
+		},
+		{
+			name:      "zero weight",
+			requested: 1,
+			capacity:  4,
+			weight:    0,
+			expected:  0,
+		},
+		{
+			name:      "zero capacity",
+			requested: 1,
+			capacity:  0,
+			weight:    1,
+			expected:  0,
+		},
+	}
+
+	for _, test := range tests {
+		if score := resourceBinPackingScore(test.requested, test.capacity, test.used, test.weight); score != test.expected {
+			t.Errorf("case %q: expected score %v, got %v", test.name, test.expected, score)
+		}
+	}
+}
@@ -49,4 +49,30 @@ func ApplyPluginConfDefaults(option *conf.PluginOption) {
 	if option.EnabledNodeOrder == nil {
 		option.EnabledNodeOrder = &t
 	}
+	// Keep the node scoring functions disabled together with NodeOrderFn,
+	// unless they are set explicitly.
+	if option.EnabledBatchNodeOrder == nil {
+		option.EnabledBatchNodeOrder = option.EnabledNodeOrder
+	}
+	if option.EnabledNodeMap == nil {
+		option.EnabledNodeMap = option.EnabledNodeOrder
+	}
+	if option.EnabledNodeReduce == nil {
+		option.EnabledNodeReduce = option.EnabledNodeOrder
+	}
+	if option.EnabledOverused == nil {
+		option.EnabledOverused = &t
+	}
+	if option.EnabledJobValid == nil {
+		option.EnabledJobValid = &t
+	}
+	if option.EnabledJobEnqueueable == nil {
+		option.EnabledJobEnqueueable = &t
+	}
+	if option.EnabledJobStarving == nil {
+		option.EnabledJobStarving = &t
+	}
+	if option.EnabledTargetJob == nil {
+		option.EnabledTargetJob = &t
+	}
 }
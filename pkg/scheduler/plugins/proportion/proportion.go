@@ -40,6 +40,13 @@ type queueAttr struct {
 	weight  int32
 	share   float64
 
+	parent   *queueAttr
+	children []*queueAttr
+	// own is the attributes of the jobs submitted to the queue itself if it
+	// has children; they share the deserved resource of the queue with its
+	// children as if they were in a child queue of weight 1.
+	own *queueAttr
+
 	deserved  *api.Resource
 	allocated *api.Resource
 	request   *api.Resource
@@ -67,104 +74,84 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	glog.V(4).Infof("The total resource is <%v>", pp.totalResource)
 
 	// Build attributes for Queues.
+	ownAllocated := map[api.QueueID]*api.Resource{}
+	ownRequest := map[api.QueueID]*api.Resource{}
 	for _, job := range ssn.Jobs {
 		glog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
 
-		if _, found := pp.queueOpts[job.Queue]; !found {
-			queue := ssn.Queues[job.Queue]
-			attr := &queueAttr{
-				queueID: queue.UID,
-				name:    queue.Name,
-				weight:  queue.Weight,
+		attr := pp.buildQueueAttr(ssn, job.Queue)
+		if attr == nil {
+			continue
+		}
 
-				deserved:  api.EmptyResource(),
-				allocated: api.EmptyResource(),
-				request:   api.EmptyResource(),
-			}
-			pp.queueOpts[job.Queue] = attr
-			glog.V(4).Infof("Added Queue <%s> attributes.", job.Queue)
+		if _, found := ownRequest[attr.queueID]; !found {
+			ownAllocated[attr.queueID] = api.EmptyResource()
+			ownRequest[attr.queueID] = api.EmptyResource()
 		}
 
 		for status, tasks := range job.TaskStatusIndex {
 			if api.AllocatedStatus(status) {
 				for _, t := range tasks {
-					attr := pp.queueOpts[job.Queue]
-					attr.allocated.Add(t.Resreq)
-					attr.request.Add(t.Resreq)
+					ownAllocated[attr.queueID].Add(t.Resreq)
+					ownRequest[attr.queueID].Add(t.Resreq)
+					for a := attr; a != nil; a = a.parent {
+						a.allocated.Add(t.Resreq)
+						a.request.Add(t.Resreq)
+					}
 				}
 			} else if status == api.Pending {
 				for _, t := range tasks {
-					attr := pp.queueOpts[job.Queue]
-					attr.request.Add(t.Resreq)
+					ownRequest[attr.queueID].Add(t.Resreq)
+					for a := attr; a != nil; a = a.parent {
+						a.request.Add(t.Resreq)
+					}
 				}
 			}
 		}
 	}
 
-	remaining := pp.totalResource.Clone()
-	meet := map[api.QueueID]struct{}{}
-	for {
-		totalWeight := int32(0)
-		for _, attr := range pp.queueOpts {
-			if _, found := meet[attr.queueID]; found {
-				continue
-			}
-			totalWeight += attr.weight
+	// Jobs submitted to a parent queue compete with its child queues.
+	for queueID, request := range ownRequest {
+		attr := pp.queueOpts[queueID]
+		if len(attr.children) == 0 {
+			continue
 		}
-
-		// If no queues, break
-		if totalWeight == 0 {
-			glog.V(4).Infof("Exiting when total weight is 0")
-			break
-		}
-
-		// Calculates the deserved of each Queue.
-		// increasedDeserved is the increased value for attr.deserved of processed queues
-		// decreasedDeserved is the decreased value for attr.deserved of processed queues
-		increasedDeserved := api.EmptyResource()
-		decreasedDeserved := api.EmptyResource()
-		for _, attr := range pp.queueOpts {
-			glog.V(4).Infof("Considering Queue <%s>: weight <%d>, total weight <%d>.",
-				attr.name, attr.weight, totalWeight)
-			if _, found := meet[attr.queueID]; found {
-				continue
-			}
-
-			oldDeserved := attr.deserved.Clone()
-			attr.deserved.Add(remaining.Clone().Multi(float64(attr.weight) / float64(totalWeight)))
-
-			if attr.request.Less(attr.deserved) {
-				attr.deserved = helpers.Min(attr.deserved, attr.request)
-				meet[attr.queueID] = struct{}{}
-				glog.V(4).Infof("queue <%s> is meet", attr.name)
-
-			}
-			pp.updateShare(attr)
-
-			glog.V(4).Infof("The attributes of queue <%s> in proportion: deserved <%v>, allocate <%v>, request <%v>, share <%0.2f>",
-				attr.name, attr.deserved, attr.allocated, attr.request, attr.share)
-
-			increased, decreased := attr.deserved.Diff(oldDeserved)
-			increasedDeserved.Add(increased)
-			decreasedDeserved.Add(decreased)
+		attr.own = &queueAttr{
+			queueID: attr.queueID,
+			name:    attr.name,
+			weight:  1,
+			parent:  attr,
+
+			deserved:  api.EmptyResource(),
+			allocated: ownAllocated[queueID],
+			request:   request,
 		}
+		attr.children = append(attr.children, attr.own)
+	}
 
-		remaining.Sub(increasedDeserved).Add(decreasedDeserved)
-		if remaining.IsEmpty() {
-			glog.V(4).Infof("Exiting when remaining is empty:  <%v>", remaining)
-			break
+	// Divide the total resource between top level queues firstly, and then
+	// divide the deserved resource of each queue between its child queues.
+	var roots []*queueAttr
+	for _, attr := range pp.queueOpts {
+		if attr.parent == nil {
+			roots = append(roots, attr)
 		}
 	}
+	pp.divideDeserved(pp.totalResource, roots)
 
 	ssn.AddQueueOrderFn(pp.Name(), func(l, r interface{}) int {
 		lv := l.(*api.QueueInfo)
 		rv := r.(*api.QueueInfo)
 
-		if pp.queueOpts[lv.UID].share == pp.queueOpts[rv.UID].share {
+		// Compare the share of the ancestors which are siblings, so that the
+		// queues under a less used parent queue go first.
+		lAttr, rAttr := pp.siblingAncestors(pp.jobsAttr(lv.UID), pp.jobsAttr(rv.UID))
+
+		if lAttr.share == rAttr.share {
 			return 0
 		}
 
-		if pp.queueOpts[lv.UID].share < pp.queueOpts[rv.UID].share {
+		if lAttr.share < rAttr.share {
 			return -1
 		}
 
@@ -173,24 +160,50 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 
 	ssn.AddReclaimableFn(pp.Name(), func(reclaimer *api.TaskInfo, reclaimees []*api.TaskInfo) []*api.TaskInfo {
 		var victims []*api.TaskInfo
-		allocations := map[api.QueueID]*api.Resource{}
+		allocations := map[*queueAttr]*api.Resource{}
+
+		// The ancestors shared with the reclaimer are not changed by reclaiming.
+		shared := map[*queueAttr]bool{}
+		if job, found := ssn.Jobs[reclaimer.Job]; found {
+			for attr := pp.jobsAttr(job.Queue); attr != nil; attr = attr.parent {
+				shared[attr] = true
+			}
+		}
 
 		for _, reclaimee := range reclaimees {
 			job := ssn.Jobs[reclaimee.Job]
-			attr := pp.queueOpts[job.Queue]
 
-			if _, found := allocations[job.Queue]; !found {
-				allocations[job.Queue] = attr.allocated.Clone()
+			// The queue and its ancestors under the one shared with the
+			// reclaimer must keep their deserved resource after reclaiming.
+			attrs := []*queueAttr{pp.jobsAttr(job.Queue)}
+			for attr := attrs[0].parent; attr != nil && !shared[attr]; attr = attr.parent {
+				attrs = append(attrs, attr)
 			}
-			allocated := allocations[job.Queue]
-			if allocated.Less(reclaimee.Resreq) {
+
+			enough := true
+			for _, attr := range attrs {
+				if _, found := allocations[attr]; !found {
+					allocations[attr] = attr.allocated.Clone()
+				}
+				if allocations[attr].Less(reclaimee.Resreq) {
+					enough = false
+				}
+			}
+			if !enough {
 				glog.V(3).Infof("Failed to allocate resource for Task <%s/%s> in Queue <%s>, not enough resource.",
 					reclaimee.Namespace, reclaimee.Name, job.Queue)
 				continue
 			}
 
-			allocated.Sub(reclaimee.Resreq)
-			if attr.deserved.LessEqual(allocated) {
+			reclaimable := true
+			for _, attr := range attrs {
+				allocated := allocations[attr]
+				allocated.Sub(reclaimee.Resreq)
+				if !attr.deserved.LessEqual(allocated) {
+					reclaimable = false
+				}
+			}
+			if reclaimable {
 				victims = append(victims, reclaimee)
 			}
 		}
@@ -200,59 +213,61 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 
 	ssn.AddOverusedFn(pp.Name(), func(obj interface{}) bool {
 		queue := obj.(*api.QueueInfo)
-		attr := pp.queueOpts[queue.UID]
 
-		overused := attr.deserved.LessEqual(attr.allocated)
-		if overused {
-			glog.V(3).Infof("Queue <%v>: deserved <%v>, allocated <%v>, share <%v>",
-				queue.Name, attr.deserved, attr.allocated, attr.share)
+		// The queue is overused if any of its ancestors is overused.
+		for attr := pp.jobsAttr(queue.UID); attr != nil; attr = attr.parent {
+			if attr.deserved.LessEqual(attr.allocated) {
+				glog.V(3).Infof("Queue <%v>: deserved <%v>, allocated <%v>, share <%v>",
+					attr.name, attr.deserved, attr.allocated, attr.share)
+				return true
+			}
 		}
 
-		return overused
+		return false
 	})
 
 	ssn.AddJobEnqueueableFn(pp.Name(), func(obj interface{}) bool {
 		job := obj.(*api.JobInfo)
-		queueID := job.Queue
-		attr := pp.queueOpts[queueID]
-		queue := ssn.Queues[queueID]
-
-		// If no capability is set, always enqueue the job.
-		if len(queue.Queue.Spec.Capability) == 0 {
-			return true
-		}
+		attr := pp.queueOpts[job.Queue]
 
 		pgResource := api.NewResource(*job.PodGroup.Spec.MinResources)
-		if len(queue.Queue.Spec.Capability) == 0 {
-			glog.V(4).Infof("Capability of queue <%s> was not set, allow job <%s/%s> to Inqueue.",
-				queue.Name, job.Namespace, job.Name)
-			return true
-		}
-		// The queue resource quota limit has not reached
-		if pgResource.Clone().Add(attr.allocated).LessEqual(api.NewResource(queue.Queue.Spec.Capability)) {
-			return true
+		// The resource quota limit of the queue and its ancestors has not reached.
+		for ; attr != nil; attr = attr.parent {
+			queue := ssn.Queues[attr.queueID]
+			// If no capability is set, always enqueue the job.
+			if len(queue.Queue.Spec.Capability) == 0 {
+				glog.V(4).Infof("Capability of queue <%s> was not set, allow job <%s/%s> to Inqueue.",
+					queue.Name, job.Namespace, job.Name)
+				continue
+			}
+
+			if !pgResource.Clone().Add(attr.allocated).LessEqual(api.NewResource(queue.Queue.Spec.Capability)) {
+				return false
+			}
 		}
-		return false
+		return true
 	})
 
 	// Register event handlers.
 	ssn.AddEventHandler(&framework.EventHandler{
 		AllocateFunc: func(event *framework.Event) {
 			job := ssn.Jobs[event.Task.Job]
-			attr := pp.queueOpts[job.Queue]
-			attr.allocated.Add(event.Task.Resreq)
-
-			pp.updateShare(attr)
+			attr := pp.jobsAttr(job.Queue)
+			for a := attr; a != nil; a = a.parent {
+				a.allocated.Add(event.Task.Resreq)
+				pp.updateShare(a)
+			}
 
 			glog.V(4).Infof("Proportion AllocateFunc: task <%v/%v>, resreq <%v>,  share <%v>",
 				event.Task.Namespace, event.Task.Name, event.Task.Resreq, attr.share)
 		},
 		DeallocateFunc: func(event *framework.Event) {
 			job := ssn.Jobs[event.Task.Job]
-			attr := pp.queueOpts[job.Queue]
-			attr.allocated.Sub(event.Task.Resreq)
-
-			pp.updateShare(attr)
+			attr := pp.jobsAttr(job.Queue)
+			for a := attr; a != nil; a = a.parent {
+				a.allocated.Sub(event.Task.Resreq)
+				pp.updateShare(a)
+			}
 
 			glog.V(4).Infof("Proportion EvictFunc: task <%v/%v>, resreq <%v>,  share <%v>",
 				event.Task.Namespace, event.Task.Name, event.Task.Resreq, attr.share)
@@ -278,3 +293,152 @@ func (pp *proportionPlugin) updateShare(attr *queueAttr) {
 
 	attr.share = res
 }
+
+// jobsAttr returns the attributes of the jobs submitted to the queue.
+func (pp *proportionPlugin) jobsAttr(queueID api.QueueID) *queueAttr {
+	attr := pp.queueOpts[queueID]
+	if attr != nil && attr.own != nil {
+		return attr.own
+	}
+	return attr
+}
+
+// buildQueueAttr returns the attributes of the queue, building them for the
+// queue and its ancestors if not found.
+func (pp *proportionPlugin) buildQueueAttr(ssn *framework.Session, queueID api.QueueID) *queueAttr {
+	var child *queueAttr
+	var leaf *queueAttr
+	visited := map[api.QueueID]bool{}
+
+	for id := queueID; id != ""; {
+		if visited[id] {
+			glog.Warningf("Found cycle in parent of Queue <%s>, ignore it.", id)
+			break
+		}
+		visited[id] = true
+
+		attr, found := pp.queueOpts[id]
+		if !found {
+			queue, found := ssn.Queues[id]
+			if !found {
+				glog.Warningf("Failed to find Queue <%s> in proportion.", id)
+				break
+			}
+			attr = &queueAttr{
+				queueID: queue.UID,
+				name:    queue.Name,
+				weight:  queue.Weight,
+
+				deserved:  api.EmptyResource(),
+				allocated: api.EmptyResource(),
+				request:   api.EmptyResource(),
+			}
+			pp.queueOpts[id] = attr
+			glog.V(4).Infof("Added Queue <%s> attributes.", id)
+		}
+
+		if leaf == nil {
+			leaf = attr
+		}
+		if child != nil {
+			child.parent = attr
+			attr.children = append(attr.children, child)
+		}
+		// Ancestors are linked already.
+		if found {
+			break
+		}
+
+		child = attr
+		id = ssn.Queues[id].Parent
+	}
+
+	return leaf
+}
+
+// divideDeserved divides the total resource between the sibling queues by
+// their weight, then divides the deserved resource of each queue between
+// its children recursively.
+func (pp *proportionPlugin) divideDeserved(total *api.Resource, attrs []*queueAttr) {
+	remaining := total.Clone()
+	meet := map[api.QueueID]struct{}{}
+	for {
+		totalWeight := int32(0)
+		for _, attr := range attrs {
+			if _, found := meet[attr.queueID]; found {
+				continue
+			}
+			totalWeight += attr.weight
+		}
+
+		// If no queues, break
+		if totalWeight == 0 {
+			glog.V(4).Infof("Exiting when total weight is 0")
+			break
+		}
+
+		// Calculates the deserved of each Queue.
+		// increasedDeserved is the increased value for attr.deserved of processed queues
+		// decreasedDeserved is the decreased value for attr.deserved of processed queues
+		increasedDeserved := api.EmptyResource()
+		decreasedDeserved := api.EmptyResource()
+		for _, attr := range attrs {
+			glog.V(4).Infof("Considering Queue <%s>: weight <%d>, total weight <%d>.",
+				attr.name, attr.weight, totalWeight)
+			if _, found := meet[attr.queueID]; found {
+				continue
+			}
+
+			oldDeserved := attr.deserved.Clone()
+			attr.deserved.Add(remaining.Clone().Multi(float64(attr.weight) / float64(totalWeight)))
+
+			if attr.request.Less(attr.deserved) {
+				attr.deserved = helpers.Min(attr.deserved, attr.request)
+				meet[attr.queueID] = struct{}{}
+				glog.V(4).Infof("queue <%s> is meet", attr.name)
+
+			}
+			pp.updateShare(attr)
+
+			glog.V(4).Infof("The attributes of queue <%s> in proportion: deserved <%v>, allocate <%v>, request <%v>, share <%0.2f>",
+				attr.name, attr.deserved, attr.allocated, attr.request, attr.share)
+
+			increased, decreased := attr.deserved.Diff(oldDeserved)
+			increasedDeserved.Add(increased)
+			decreasedDeserved.Add(decreased)
+		}
+
+		remaining.Sub(increasedDeserved).Add(decreasedDeserved)
+		if remaining.IsEmpty() {
+			glog.V(4).Infof("Exiting when remaining is empty:  <%v>", remaining)
+			break
+		}
+	}
+
+	for _, attr := range attrs {
+		if len(attr.children) != 0 {
+			pp.divideDeserved(attr.deserved, attr.children)
+		}
+	}
+}
+
+// siblingAncestors returns the ancestors (or the queues themselves) of l and r
+// which have the same parent.
+func (pp *proportionPlugin) siblingAncestors(l, r *queueAttr) (*queueAttr, *queueAttr) {
+	path := func(attr *queueAttr) []*queueAttr {
+		var res []*queueAttr
+		for ; attr != nil; attr = attr.parent {
+			res = append([]*queueAttr{attr}, res...)
+		}
+		return res
+	}
+
+	lPath, rPath := path(l), path(r)
+	for i := 0; i < len(lPath) && i < len(rPath); i++ {
+		if lPath[i] != rPath[i] {
+			return lPath[i], rPath[i]
+		}
+	}
+
+	return l, r
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proportion
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestHierarchicalDeserved(t *testing.T) {
+	queues := []*kbv1.Queue{
+		{ObjectMeta: metav1.ObjectMeta{Name: "root"}, Spec: kbv1.QueueSpec{Weight: 1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: kbv1.QueueSpec{Weight: 1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: kbv1.QueueSpec{Weight: 1, Parent: "root"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: kbv1.QueueSpec{Weight: 1, Parent: "root"}},
+	}
+
+	tests := []struct {
+		name string
+		// pods is the number of pending pods, 1 cpu each, submitted to each queue.
+		pods map[string]int
+		// expected is the deserved milli cpu of each queue; "root/own" is the
+		// jobs submitted to root queue itself.
+		expected map[string]float64
+	}{
+		{
+			name: "child queues share the deserved resource of parent",
+			pods: map[string]int{"other": 12, "a": 12, "b": 12},
+			expected: map[string]float64{
+				"root":  6000,
+				"other": 6000,
+				"a":     3000,
+				"b":     3000,
+			},
+		},
+		{
+			name: "jobs of parent queue share with child queues",
+			pods: map[string]int{"other": 12, "root": 12, "a": 12, "b": 12},
+			expected: map[string]float64{
+				"root":     6000,
+				"other":    6000,
+				"root/own": 2000,
+				"a":        2000,
+				"b":        2000,
+			},
+		},
+		{
+			name: "child queues get the resource not requested by jobs of parent queue",
+			pods: map[string]int{"other": 12, "root": 1, "a": 12, "b": 12},
+			expected: map[string]float64{
+				"root":     6000,
+				"other":    6000,
+				"root/own": 1000,
+				"a":        2500,
+				"b":        2500,
+			},
+		},
+		{
+			name: "parent queue gets the resource not requested by its sibling",
+			pods: map[string]int{"other": 2, "a": 12, "b": 12},
+			expected: map[string]float64{
+				"root":  10000,
+				"other": 2000,
+				"a":     5000,
+				"b":     5000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		schedulerCache := &cache.SchedulerCache{
+			Nodes:         make(map[string]*api.NodeInfo),
+			Jobs:          make(map[api.JobID]*api.JobInfo),
+			Queues:        make(map[api.QueueID]*api.QueueInfo),
+			Binder:        &util.FakeBinder{Binds: map[string]string{}, Channel: make(chan string, 10)},
+			StatusUpdater: &util.FakeStatusUpdater{},
+			VolumeBinder:  &util.FakeVolumeBinder{},
+
+			Recorder: record.NewFakeRecorder(100),
+		}
+
+		schedulerCache.AddNode(util.BuildNode("n1", util.BuildResourceListWithGPU("12", "24Gi", "4"), make(map[string]string)))
+		for _, queue := range queues {
+			schedulerCache.AddQueue(queue)
+		}
+		for queue, pods := range test.pods {
+			schedulerCache.AddPodGroup(&kbv1.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: queue, Namespace: "c1"},
+				Spec:       kbv1.PodGroupSpec{Queue: queue},
+			})
+			for i := 0; i < pods; i++ {
+				schedulerCache.AddPod(util.BuildPod("c1", fmt.Sprintf("%s-%d", queue, i), "", v1.PodPending,
+					util.BuildResourceList("1", "1Gi"), queue, make(map[string]string), make(map[string]string)))
+			}
+		}
+
+		pp := New(framework.Arguments{}).(*proportionPlugin)
+		framework.RegisterPluginBuilder(PluginName, func(framework.Arguments) framework.Plugin { return pp })
+		ssn := framework.OpenSession(schedulerCache, []conf.Tier{
+			{Plugins: []conf.PluginOption{{Name: PluginName}}},
+		})
+
+		for name, expected := range test.expected {
+			attr := pp.queueOpts[api.QueueID(name)]
+			if name == "root/own" {
+				attr = pp.queueOpts["root"].own
+			}
+			if attr == nil {
+				t.Errorf("case %q: no attributes of queue %s", test.name, name)
+				continue
+			}
+			if attr.deserved.MilliCPU != expected {
+				t.Errorf("case %q: expected deserved cpu %v of queue %s, got %v",
+					test.name, expected, name, attr.deserved.MilliCPU)
+			}
+		}
+		if _, found := test.expected["root/own"]; !found && pp.queueOpts["root"].own != nil {
+			t.Errorf("case %q: unexpected attributes of jobs in root queue", test.name)
+		}
+
+		framework.CloseSession(ssn)
+		framework.CleanupPluginBuilders()
+	}
+}
+
+func TestHierarchicalReclaimable(t *testing.T) {
+	queues := []*kbv1.Queue{
+		{ObjectMeta: metav1.ObjectMeta{Name: "root"}, Spec: kbv1.QueueSpec{Weight: 1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: kbv1.QueueSpec{Weight: 1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: kbv1.QueueSpec{Weight: 1, Parent: "root"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: kbv1.QueueSpec{Weight: 1, Parent: "root"}},
+	}
+	// The deserved cpu of root and other is 6, and 3 of a and b; queue a
+	// uses 4 cpu, but root only uses 5 cpu.
+	running := map[string]int{"a": 4, "b": 1, "other": 7}
+
+	tests := []struct {
+		name      string
+		reclaimer string
+		reclaimee string
+		expected  bool
+	}{
+		{
+			name:      "child queue over deserved under parent queue under deserved",
+			reclaimer: "other",
+			reclaimee: "a",
+			expected:  false,
+		},
+		{
+			name:      "sibling queue reclaims from child queue over deserved",
+			reclaimer: "b",
+			reclaimee: "a",
+			expected:  true,
+		},
+		{
+			name:      "root queue over deserved",
+			reclaimer: "a",
+			reclaimee: "other",
+			expected:  true,
+		},
+	}
+
+	schedulerCache := &cache.SchedulerCache{
+		Nodes:         make(map[string]*api.NodeInfo),
+		Jobs:          make(map[api.JobID]*api.JobInfo),
+		Queues:        make(map[api.QueueID]*api.QueueInfo),
+		Binder:        &util.FakeBinder{Binds: map[string]string{}, Channel: make(chan string, 10)},
+		StatusUpdater: &util.FakeStatusUpdater{},
+		VolumeBinder:  &util.FakeVolumeBinder{},
+
+		Recorder: record.NewFakeRecorder(100),
+	}
+
+	schedulerCache.AddNode(util.BuildNode("n1", util.BuildResourceList("12", "24Gi"), make(map[string]string)))
+	for _, queue := range queues {
+		schedulerCache.AddQueue(queue)
+	}
+	for _, queue := range []string{"a", "b", "other"} {
+		schedulerCache.AddPodGroup(&kbv1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: queue, Namespace: "c1"},
+			Spec:       kbv1.PodGroupSpec{Queue: queue},
+		})
+		for i := 0; i < running[queue]; i++ {
+			schedulerCache.AddPod(util.BuildPod("c1", fmt.Sprintf("%s-running-%d", queue, i), "n1", v1.PodRunning,
+				util.BuildResourceList("1", "2Gi"), queue, make(map[string]string), make(map[string]string)))
+		}
+		for i := 0; i < 8; i++ {
+			schedulerCache.AddPod(util.BuildPod("c1", fmt.Sprintf("%s-pending-%d", queue, i), "", v1.PodPending,
+				util.BuildResourceList("1", "2Gi"), queue, make(map[string]string), make(map[string]string)))
+		}
+	}
+
+	trueValue := true
+	pp := New(framework.Arguments{}).(*proportionPlugin)
+	framework.RegisterPluginBuilder(PluginName, func(framework.Arguments) framework.Plugin { return pp })
+	defer framework.CleanupPluginBuilders()
+	ssn := framework.OpenSession(schedulerCache, []conf.Tier{
+		{Plugins: []conf.PluginOption{{Name: PluginName, EnabledReclaimable: &trueValue}}},
+	})
+	defer framework.CloseSession(ssn)
+
+	for _, test := range tests {
+		reclaimer := ssn.Jobs[api.JobID("c1/"+test.reclaimer)].TaskStatusIndex[api.Pending]
+		reclaimee := ssn.Jobs[api.JobID("c1/"+test.reclaimee)].TaskStatusIndex[api.Running]
+
+		var reclaimerTask, reclaimeeTask *api.TaskInfo
+		for _, task := range reclaimer {
+			reclaimerTask = task
+			break
+		}
+		for _, task := range reclaimee {
+			reclaimeeTask = task
+			break
+		}
+
+		victims := ssn.Reclaimable(reclaimerTask, []*api.TaskInfo{reclaimeeTask})
+		if reclaimable := len(victims) != 0; reclaimable != test.expected {
+			t.Errorf("case %q: expected reclaimable %t, got %t", test.name, test.expected, reclaimable)
+		}
+	}
+}
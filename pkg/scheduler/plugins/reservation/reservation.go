@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"github.com/golang/glog"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// PluginName indicates name of volcano scheduler plugin.
+const PluginName = "reservation"
+
+type reservationPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+}
+
+// New return reservation plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	return &reservationPlugin{pluginArguments: arguments}
+}
+
+func (rp *reservationPlugin) Name() string {
+	return PluginName
+}
+
+func (rp *reservationPlugin) OnSessionOpen(ssn *framework.Session) {
+	/*
+	   The reserve action locks nodes for the job selected by this plugin:
+	   starving jobs go first, then the ones with higher priority, then the
+	   ones created earlier.
+
+	   actions: "enqueue, allocate, reserve, backfill"
+	   tiers:
+	   - plugins:
+	     - name: sla
+	     - name: priority
+	     - name: gang
+	     - name: reservation
+	*/
+	targetJobFn := func(jobs []*api.JobInfo) *api.JobInfo {
+		var target *api.JobInfo
+		var targetStarving bool
+
+		for _, job := range jobs {
+			starving := ssn.JobStarving(job)
+			if target == nil || precedes(job, starving, target, targetStarving) {
+				target, targetStarving = job, starving
+			}
+		}
+
+		if target != nil {
+			glog.V(4).Infof("Reservation TargetJobFn: selected Job <%s/%s>, priority: %d, starving: %t",
+				target.Namespace, target.Name, target.Priority, targetStarving)
+		}
+
+		return target
+	}
+
+	ssn.AddTargetJobFn(rp.Name(), targetJobFn)
+}
+
+// precedes returns whether job l should be targeted before job r.
+func precedes(l *api.JobInfo, lStarving bool, r *api.JobInfo, rStarving bool) bool {
+	if lStarving != rStarving {
+		return lStarving
+	}
+	if l.Priority != r.Priority {
+		return l.Priority > r.Priority
+	}
+	if !l.CreationTimestamp.Equal(&r.CreationTimestamp) {
+		return l.CreationTimestamp.Before(&r.CreationTimestamp)
+	}
+	return l.UID < r.UID
+}
+
+func (rp *reservationPlugin) OnSessionClose(ssn *framework.Session) {}
@@ -0,0 +1,176 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sla
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+
+	"volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "sla"
+
+	// JobWaitingTime is the key of the plugin argument and the PodGroup annotation
+	// for the maximum time a job could wait before it is scheduled, e.g. "30m".
+	JobWaitingTime = "sla-waiting-time"
+	// JobWaitingTimeAnnotationKey is the PodGroup annotation to set waiting time per job,
+	// which takes precedence over the plugin argument.
+	JobWaitingTimeAnnotationKey = "volcano.sh/" + JobWaitingTime
+)
+
+type slaPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+
+	jobWaitingTime *time.Duration
+}
+
+// New function returns slaPlugin object
+func New(arguments framework.Arguments) framework.Plugin {
+	sp := &slaPlugin{pluginArguments: arguments}
+
+	/*
+	   User could configure the global waiting time of jobs in the plugin arguments,
+	   and override it per job by the "volcano.sh/sla-waiting-time" annotation.
+
+	   actions: "enqueue, allocate, backfill"
+	   tiers:
+	   - plugins:
+	     - name: sla
+	       arguments:
+	         sla-waiting-time: 1h
+	     - name: priority
+	     - name: gang
+	*/
+	if value, found := arguments[JobWaitingTime]; found && value != "" {
+		if waitingTime, err := time.ParseDuration(value); err != nil || waitingTime <= 0 {
+			glog.Warningf("Could not parse argument: %s for key %s, with err %v", value, JobWaitingTime, err)
+		} else {
+			sp.jobWaitingTime = &waitingTime
+		}
+	}
+
+	return sp
+}
+
+func (sp *slaPlugin) Name() string {
+	return PluginName
+}
+
+// readJobWaitingTime returns the waiting time of the job, nil if not set.
+func (sp *slaPlugin) readJobWaitingTime(job *api.JobInfo) *time.Duration {
+	if job.PodGroup != nil {
+		if value, found := job.PodGroup.Annotations[JobWaitingTimeAnnotationKey]; found && value != "" {
+			waitingTime, err := time.ParseDuration(value)
+			if err == nil && waitingTime > 0 {
+				return &waitingTime
+			}
+			glog.Warningf("Invalid %s annotation <%s> of job <%s/%s>: %v",
+				JobWaitingTimeAnnotationKey, value, job.Namespace, job.Name, err)
+		}
+	}
+
+	return sp.jobWaitingTime
+}
+
+// deadline returns the time when the job starts starving, and whether the job has SLA.
+func (sp *slaPlugin) deadline(job *api.JobInfo) (time.Time, bool) {
+	if job.PodGroup == nil {
+		return time.Time{}, false
+	}
+
+	// Only jobs which are waiting for resources could be starving.
+	if phase := job.PodGroup.Status.Phase; phase != v1alpha1.PodGroupPending && phase != v1alpha1.PodGroupInqueue {
+		return time.Time{}, false
+	}
+
+	waitingTime := sp.readJobWaitingTime(job)
+	if waitingTime == nil {
+		return time.Time{}, false
+	}
+
+	return job.CreationTimestamp.Add(*waitingTime), true
+}
+
+func (sp *slaPlugin) starving(job *api.JobInfo) bool {
+	deadline, found := sp.deadline(job)
+	return found && !time.Now().Before(deadline)
+}
+
+func (sp *slaPlugin) OnSessionOpen(ssn *framework.Session) {
+	jobOrderFn := func(l, r interface{}) int {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+
+		lStarving := sp.starving(lv)
+		rStarving := sp.starving(rv)
+
+		glog.V(4).Infof("SLA JobOrderFn: <%v/%v> starving: %t, <%v/%v> starving: %t",
+			lv.Namespace, lv.Name, lStarving, rv.Namespace, rv.Name, rStarving)
+
+		if lStarving && !rStarving {
+			return -1
+		}
+		if !lStarving && rStarving {
+			return 1
+		}
+		if !lStarving && !rStarving {
+			return 0
+		}
+
+		// Both are starving, the one which missed deadline earlier goes first.
+		lDeadline, _ := sp.deadline(lv)
+		rDeadline, _ := sp.deadline(rv)
+		if lDeadline.Before(rDeadline) {
+			return -1
+		}
+		if rDeadline.Before(lDeadline) {
+			return 1
+		}
+
+		return 0
+	}
+
+	ssn.AddJobOrderFn(sp.Name(), jobOrderFn)
+
+	jobEnqueueVoteFn := func(obj interface{}) int {
+		job := obj.(*api.JobInfo)
+		if sp.starving(job) {
+			glog.V(3).Infof("Job <%s/%s> exceeded its waiting time, permit it to Inqueue",
+				job.Namespace, job.Name)
+			return api.Permit
+		}
+
+		return api.Abstain
+	}
+
+	ssn.AddJobEnqueueVoteFn(sp.Name(), jobEnqueueVoteFn)
+
+	jobStarvingFn := func(obj interface{}) bool {
+		return sp.starving(obj.(*api.JobInfo))
+	}
+
+	ssn.AddJobStarvingFn(sp.Name(), jobStarvingFn)
+}
+
+func (sp *slaPlugin) OnSessionClose(ssn *framework.Session) {}
@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sla
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+func buildJob(name string, created time.Duration, phase v1alpha1.PodGroupPhase, annotations map[string]string) *api.JobInfo {
+	job := api.NewJobInfo(api.JobID("c1/" + name))
+	job.Name = name
+	job.Namespace = "c1"
+	job.CreationTimestamp = metav1.NewTime(time.Now().Add(-created))
+	job.PodGroup = &v1alpha1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "c1",
+			Annotations: annotations,
+		},
+		Status: v1alpha1.PodGroupStatus{Phase: phase},
+	}
+	return job
+}
+
+func TestStarving(t *testing.T) {
+	sp := New(framework.Arguments{JobWaitingTime: "1h"}).(*slaPlugin)
+
+	tests := []struct {
+		name     string
+		job      *api.JobInfo
+		expected bool
+	}{
+		{
+			name:     "pending job within waiting time",
+			job:      buildJob("j1", 10*time.Minute, v1alpha1.PodGroupPending, nil),
+			expected: false,
+		},
+		{
+			name:     "pending job exceeds waiting time",
+			job:      buildJob("j2", 2*time.Hour, v1alpha1.PodGroupPending, nil),
+			expected: true,
+		},
+		{
+			name:     "inqueue job exceeds waiting time",
+			job:      buildJob("j3", 2*time.Hour, v1alpha1.PodGroupInqueue, nil),
+			expected: true,
+		},
+		{
+			name:     "running job is never starving",
+			job:      buildJob("j4", 2*time.Hour, v1alpha1.PodGroupRunning, nil),
+			expected: false,
+		},
+		{
+			name: "annotation overrides plugin argument",
+			job: buildJob("j5", 10*time.Minute, v1alpha1.PodGroupPending,
+				map[string]string{JobWaitingTimeAnnotationKey: "5m"}),
+			expected: true,
+		},
+		{
+			name: "invalid annotation falls back to plugin argument",
+			job: buildJob("j6", 10*time.Minute, v1alpha1.PodGroupPending,
+				map[string]string{JobWaitingTimeAnnotationKey: "soon"}),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		if starving := sp.starving(test.job); starving != test.expected {
+			t.Errorf("case %q: expected starving %t, got %t", test.name, test.expected, starving)
+		}
+	}
+
+	// Without waiting time, no job is starving.
+	sp = New(framework.Arguments{}).(*slaPlugin)
+	if sp.starving(buildJob("j7", 24*time.Hour, v1alpha1.PodGroupPending, nil)) {
+		t.Errorf("expected job without waiting time not to be starving")
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasktopology
+
+import (
+	"strings"
+
+	"github.com/golang/glog"
+
+	schedulerapi "k8s.io/kubernetes/pkg/scheduler/api"
+
+	batch "volcano.sh/volcano/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "task-topology"
+
+	// TopologyWeight is the key for providing Task Topology Priority Weight in YAML
+	TopologyWeight = "task-topology.weight"
+
+	// AffinityAnnotationKey is the PodGroup annotation listing groups of tasks
+	// which should be co-located, e.g. "ps,worker;driver,executor".
+	AffinityAnnotationKey = "volcano.sh/task-topology-affinity"
+	// AntiAffinityAnnotationKey is the PodGroup annotation listing groups of tasks
+	// which should be spread, e.g. "worker" or "ps;worker".
+	AntiAffinityAnnotationKey = "volcano.sh/task-topology-anti-affinity"
+	// TaskOrderAnnotationKey is the PodGroup annotation listing the order in which
+	// tasks should be scheduled, e.g. "ps,worker".
+	TaskOrderAnnotationKey = "volcano.sh/task-topology-task-order"
+
+	groupSeparator = ";"
+	taskSeparator  = ","
+)
+
+// jobTopology is the topology hints of one job parsed from its PodGroup annotations.
+type jobTopology struct {
+	affinity     [][]string
+	antiAffinity [][]string
+	// taskOrder is the scheduling priority of each task name, lower first.
+	taskOrder map[string]int
+}
+
+type taskTopologyPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+
+	weight int
+	jobs   map[api.JobID]*jobTopology
+}
+
+// New function returns taskTopologyPlugin object
+func New(arguments framework.Arguments) framework.Plugin {
+	return &taskTopologyPlugin{
+		pluginArguments: arguments,
+		weight:          1,
+		jobs:            map[api.JobID]*jobTopology{},
+	}
+}
+
+func (tp *taskTopologyPlugin) Name() string {
+	return PluginName
+}
+
+func parseGroups(value string) [][]string {
+	var groups [][]string
+	for _, group := range strings.Split(value, groupSeparator) {
+		var tasks []string
+		for _, task := range strings.Split(group, taskSeparator) {
+			if task = strings.TrimSpace(task); task != "" {
+				tasks = append(tasks, task)
+			}
+		}
+		if len(tasks) != 0 {
+			groups = append(groups, tasks)
+		}
+	}
+	return groups
+}
+
+func newJobTopology(annotations map[string]string) *jobTopology {
+	topo := &jobTopology{
+		affinity:     parseGroups(annotations[AffinityAnnotationKey]),
+		antiAffinity: parseGroups(annotations[AntiAffinityAnnotationKey]),
+		taskOrder:    map[string]int{},
+	}
+
+	if len(topo.affinity) == 0 && len(topo.antiAffinity) == 0 {
+		return nil
+	}
+
+	addOrder := func(groups [][]string) {
+		for _, group := range groups {
+			for _, task := range group {
+				if _, found := topo.taskOrder[task]; !found {
+					topo.taskOrder[task] = len(topo.taskOrder)
+				}
+			}
+		}
+	}
+	// Explicit order goes first, then tasks with affinity so that the tasks
+	// they follow are already placed, then tasks which are only spread.
+	addOrder(parseGroups(annotations[TaskOrderAnnotationKey]))
+	addOrder(topo.affinity)
+	addOrder(topo.antiAffinity)
+
+	return topo
+}
+
+func getTaskName(task *api.TaskInfo) string {
+	if task.Pod == nil || task.Pod.Annotations == nil {
+		return ""
+	}
+	return task.Pod.Annotations[batch.TaskSpecKey]
+}
+
+func contains(group []string, name string) bool {
+	for _, n := range group {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// spread returns whether the tasks of the two names should be spread.
+func (topo *jobTopology) spread(taskName, name string) bool {
+	for _, group := range topo.antiAffinity {
+		if contains(group, taskName) && contains(group, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// score returns the count of peers on node which the task should be co-located with,
+// minus the count of peers which the task should be spread from. Anti-affinity wins
+// if both apply, e.g. workers with affinity "ps,worker" and anti-affinity "worker"
+// are co-located with ps but spread from each other.
+func (topo *jobTopology) score(taskName string, peers map[string]int) int {
+	score := 0
+	for _, group := range topo.affinity {
+		if !contains(group, taskName) {
+			continue
+		}
+		for _, name := range group {
+			if topo.spread(taskName, name) {
+				continue
+			}
+			score += peers[name]
+		}
+	}
+	for _, group := range topo.antiAffinity {
+		if !contains(group, taskName) {
+			continue
+		}
+		for _, name := range group {
+			score -= peers[name]
+		}
+	}
+	return score
+}
+
+func (tp *taskTopologyPlugin) OnSessionOpen(ssn *framework.Session) {
+	tp.pluginArguments.GetInt(&tp.weight, TopologyWeight)
+
+	for _, job := range ssn.Jobs {
+		if job.PodGroup == nil {
+			continue
+		}
+		if topo := newJobTopology(job.PodGroup.Annotations); topo != nil {
+			glog.V(4).Infof("Task topology of job <%s/%s>: affinity %v, anti-affinity %v, order %v",
+				job.Namespace, job.Name, topo.affinity, topo.antiAffinity, topo.taskOrder)
+			tp.jobs[job.UID] = topo
+		}
+	}
+
+	taskOrderFn := func(l interface{}, r interface{}) int {
+		lv := l.(*api.TaskInfo)
+		rv := r.(*api.TaskInfo)
+
+		if lv.Job != rv.Job {
+			return 0
+		}
+		topo, found := tp.jobs[lv.Job]
+		if !found {
+			return 0
+		}
+
+		lOrder, lFound := topo.taskOrder[getTaskName(lv)]
+		rOrder, rFound := topo.taskOrder[getTaskName(rv)]
+
+		glog.V(4).Infof("Task topology TaskOrder: <%v/%v> order is %v, <%v/%v> order is %v",
+			lv.Namespace, lv.Name, lOrder, rv.Namespace, rv.Name, rOrder)
+
+		switch {
+		case lFound && !rFound:
+			return -1
+		case !lFound && rFound:
+			return 1
+		case lOrder < rOrder:
+			return -1
+		case lOrder > rOrder:
+			return 1
+		}
+
+		return 0
+	}
+
+	ssn.AddTaskOrderFn(tp.Name(), taskOrderFn)
+
+	nodeOrderFn := func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
+		topo, found := tp.jobs[task.Job]
+		if !found {
+			return 0, nil
+		}
+		taskName := getTaskName(task)
+		if taskName == "" {
+			return 0, nil
+		}
+
+		job, found := ssn.Jobs[task.Job]
+		if !found || len(job.Tasks) == 0 {
+			return 0, nil
+		}
+
+		// Count tasks of the same job already placed on the node, by task name.
+		peers := map[string]int{}
+		for _, t := range node.Tasks {
+			if t.Job != task.Job || t.UID == task.UID {
+				continue
+			}
+			if !api.AllocatedStatus(t.Status) && t.Status != api.Pipelined {
+				continue
+			}
+			peers[getTaskName(t)]++
+		}
+
+		// Mapping the peer count from [-len(tasks), len(tasks)] to [-10, 10](MaxPriority)
+		score := float64(topo.score(taskName, peers)) / float64(len(job.Tasks))
+		score *= float64(schedulerapi.MaxPriority * tp.weight)
+
+		glog.V(4).Infof("Task topology score for Task %s/%s on node %s is: %v", task.Namespace, task.Name, node.Name, score)
+		return score, nil
+	}
+
+	ssn.AddNodeOrderFn(tp.Name(), nodeOrderFn)
+}
+
+func (tp *taskTopologyPlugin) OnSessionClose(ssn *framework.Session) {
+	tp.jobs = map[api.JobID]*jobTopology{}
+}
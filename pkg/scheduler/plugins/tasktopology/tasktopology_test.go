@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasktopology
+
+import (
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		taskName      string
+		peers         map[string]int
+		expectedScore int
+	}{
+		{
+			name: "worker is co-located with ps but spread from workers",
+			annotations: map[string]string{
+				AffinityAnnotationKey:     "ps,worker",
+				AntiAffinityAnnotationKey: "worker",
+			},
+			taskName:      "worker",
+			peers:         map[string]int{"ps": 1, "worker": 2},
+			expectedScore: -1,
+		},
+		{
+			name: "worker prefers node with ps and without workers",
+			annotations: map[string]string{
+				AffinityAnnotationKey:     "ps,worker",
+				AntiAffinityAnnotationKey: "worker",
+			},
+			taskName:      "worker",
+			peers:         map[string]int{"ps": 1},
+			expectedScore: 1,
+		},
+		{
+			name: "ps is co-located with ps and workers",
+			annotations: map[string]string{
+				AffinityAnnotationKey:     "ps,worker",
+				AntiAffinityAnnotationKey: "worker",
+			},
+			taskName:      "ps",
+			peers:         map[string]int{"ps": 1, "worker": 2},
+			expectedScore: 3,
+		},
+		{
+			name: "tasks of the same name are co-located without anti-affinity",
+			annotations: map[string]string{
+				AffinityAnnotationKey: "worker",
+			},
+			taskName:      "worker",
+			peers:         map[string]int{"worker": 2},
+			expectedScore: 2,
+		},
+		{
+			name: "task without topology is not scored",
+			annotations: map[string]string{
+				AntiAffinityAnnotationKey: "worker",
+			},
+			taskName:      "driver",
+			peers:         map[string]int{"worker": 2},
+			expectedScore: 0,
+		},
+	}
+
+	for _, test := range tests {
+		topo := newJobTopology(test.annotations)
+		if score := topo.score(test.taskName, test.peers); score != test.expectedScore {
+			t.Errorf("case %q: expected score %d, got %d", test.name, test.expectedScore, score)
+		}
+	}
+}
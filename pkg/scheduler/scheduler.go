@@ -39,6 +39,11 @@ type Scheduler struct {
 	plugins        []conf.Tier
 	schedulerConf  string
 	schedulePeriod time.Duration
+
+	// loadedConf is the latest configuration read from schedulerConf, and
+	// confVersion is increased every time a new configuration is applied.
+	loadedConf  string
+	confVersion int
 }
 
 // NewScheduler returns a scheduler
@@ -77,10 +82,11 @@ func (pc *Scheduler) Run(stopCh <-chan struct{}) {
 		}
 	}
 
-	pc.actions, pc.plugins, err = loadSchedulerConf(schedConf)
+	actions, plugins, err := loadSchedulerConf(schedConf)
 	if err != nil {
 		panic(err)
 	}
+	pc.applySchedulerConf(schedConf, actions, plugins)
 
 	go wait.Until(pc.runOnce, pc.schedulePeriod, stopCh)
 }
@@ -91,6 +97,8 @@ func (pc *Scheduler) runOnce() {
 	defer glog.V(4).Infof("End scheduling ...")
 	defer metrics.UpdateE2eDuration(metrics.Duration(scheduleStartTime))
 
+	pc.reloadSchedulerConf()
+
 	ssn := framework.OpenSession(pc.cache, pc.plugins)
 	defer framework.CloseSession(ssn)
 
@@ -100,3 +108,57 @@ func (pc *Scheduler) runOnce() {
 		metrics.UpdateActionDuration(action.Name(), metrics.Duration(actionStartTime))
 	}
 }
+
+// reloadSchedulerConf reloads the configuration file if it was changed since
+// last loaded; the current configuration is kept if the new one is invalid.
+// It is called between sessions, so actions and tiers are never changed in
+// the middle of a session.
+func (pc *Scheduler) reloadSchedulerConf() {
+	if len(pc.schedulerConf) == 0 {
+		return
+	}
+
+	schedConf, err := readSchedulerConf(pc.schedulerConf)
+	if err != nil {
+		glog.V(3).Infof("Failed to read scheduler configuration '%s', keep using version %d: %v",
+			pc.schedulerConf, pc.confVersion, err)
+		return
+	}
+
+	if schedConf == pc.loadedConf {
+		return
+	}
+	pc.loadedConf = schedConf
+
+	actions, plugins, err := loadSchedulerConf(schedConf)
+	if err != nil {
+		glog.Errorf("Failed to load scheduler configuration '%s', keep using version %d: %v",
+			pc.schedulerConf, pc.confVersion, err)
+		return
+	}
+
+	pc.applySchedulerConf(schedConf, actions, plugins)
+}
+
+func (pc *Scheduler) applySchedulerConf(schedConf string, actions []framework.Action, plugins []conf.Tier) {
+	pc.loadedConf = schedConf
+	pc.actions = actions
+	pc.plugins = plugins
+	pc.confVersion++
+
+	glog.Infof("Scheduler configuration version %d is loaded", pc.confVersion)
+	metrics.UpdateSchedulerConfVersion(pc.confVersion)
+
+	// The reservation is only released by reserve action, so release it
+	// if the action is not configured any more.
+	for _, action := range actions {
+		if action.Name() == "reserve" {
+			return
+		}
+	}
+	if reservation := framework.GetReservation(); len(reservation.TargetJob) != 0 {
+		glog.V(3).Infof("Release %d nodes locked for Job <%s> as reserve action is not configured",
+			len(reservation.LockedNodes), reservation.TargetJob)
+	}
+	framework.ResetReservation()
+}
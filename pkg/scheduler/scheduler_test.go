@@ -0,0 +1,158 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+func TestReloadSchedulerConf(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scheduler-conf")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	confPath := filepath.Join(dir, "scheduler.conf")
+	pc := &Scheduler{schedulerConf: confPath}
+
+	actions, plugins, err := loadSchedulerConf(defaultSchedulerConf)
+	if err != nil {
+		t.Fatalf("Failed to load default scheduler configuration: %v", err)
+	}
+	pc.applySchedulerConf(defaultSchedulerConf, actions, plugins)
+
+	testCases := []struct {
+		Name          string
+		Conf          string
+		ExpectActions []string
+		ExpectTiers   int
+		ExpectVersion int
+	}{
+		{
+			Name: "reload changed configuration",
+			Conf: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gang
+`,
+			ExpectActions: []string{"allocate"},
+			ExpectTiers:   1,
+			ExpectVersion: 2,
+		},
+		{
+			Name: "keep configuration if not changed",
+			Conf: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gang
+`,
+			ExpectActions: []string{"allocate"},
+			ExpectTiers:   1,
+			ExpectVersion: 2,
+		},
+		{
+			Name: "keep configuration if invalid",
+			Conf: `
+actions: "allocate, unknown"
+tiers:
+- plugins:
+  - name: gang
+`,
+			ExpectActions: []string{"allocate"},
+			ExpectTiers:   1,
+			ExpectVersion: 2,
+		},
+		{
+			Name: "reload configuration after fixed",
+			Conf: `
+actions: "allocate, backfill"
+tiers:
+- plugins:
+  - name: gang
+- plugins:
+  - name: drf
+`,
+			ExpectActions: []string{"allocate", "backfill"},
+			ExpectTiers:   2,
+			ExpectVersion: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		if err := ioutil.WriteFile(confPath, []byte(testCase.Conf), 0644); err != nil {
+			t.Fatalf("%s: failed to write scheduler configuration: %v", testCase.Name, err)
+		}
+
+		pc.reloadSchedulerConf()
+
+		var actionNames []string
+		for _, action := range pc.actions {
+			actionNames = append(actionNames, action.Name())
+		}
+		if !reflect.DeepEqual(actionNames, testCase.ExpectActions) {
+			t.Errorf("%s: expected actions %v, got %v", testCase.Name, testCase.ExpectActions, actionNames)
+		}
+		if len(pc.plugins) != testCase.ExpectTiers {
+			t.Errorf("%s: expected %d tiers, got %d", testCase.Name, testCase.ExpectTiers, len(pc.plugins))
+		}
+		if pc.confVersion != testCase.ExpectVersion {
+			t.Errorf("%s: expected version %d, got %d", testCase.Name, testCase.ExpectVersion, pc.confVersion)
+		}
+	}
+}
+
+func TestReleaseReservationWithoutReserveAction(t *testing.T) {
+	defer framework.ResetReservation()
+
+	pc := &Scheduler{}
+	apply := func(schedConf string) {
+		actions, plugins, err := loadSchedulerConf(schedConf)
+		if err != nil {
+			t.Fatalf("Failed to load scheduler configuration: %v", err)
+		}
+		pc.applySchedulerConf(schedConf, actions, plugins)
+	}
+
+	withReserve := `
+actions: "allocate, reserve, backfill"
+tiers:
+- plugins:
+  - name: gang
+`
+	apply(withReserve)
+	framework.GetReservation().Lock("c1/job", []string{"n1"}, time.Now())
+
+	apply(withReserve)
+	if r := framework.GetReservation(); r.TargetJob != "c1/job" || !r.LockedNodes["n1"] {
+		t.Errorf("expected nodes locked for c1/job to be kept, got %s with nodes %v", r.TargetJob, r.LockedNodes)
+	}
+
+	apply(defaultSchedulerConf)
+	if r := framework.GetReservation(); len(r.TargetJob) != 0 || len(r.LockedNodes) != 0 {
+		t.Errorf("expected reservation to be released, got %s with nodes %v", r.TargetJob, r.LockedNodes)
+	}
+}
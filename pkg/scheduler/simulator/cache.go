@@ -0,0 +1,210 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/scheduling/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+)
+
+// Binding is a task bound to a node by the simulator.
+type Binding struct {
+	Task string
+	Node string
+}
+
+// Eviction is a task evicted from a node by the simulator.
+type Eviction struct {
+	Task   string
+	Node   string
+	Reason string
+}
+
+// Cache is an in-memory implementation of cache.Cache. Objects are added
+// directly instead of being watched from api server; binding and evicting a
+// task only update the pod in memory, and are recorded for the report.
+type Cache struct {
+	sync.Mutex
+
+	cache        *schedcache.SchedulerCache
+	defaultQueue string
+
+	bindings      []Binding
+	evictions     []Eviction
+	unschedulable map[string]string
+}
+
+// NewCache returns an empty in-memory cache; jobs without queue are put into
+// defaultQueue.
+func NewCache(defaultQueue string) *Cache {
+	return &Cache{
+		cache: &schedcache.SchedulerCache{
+			Jobs:            make(map[api.JobID]*api.JobInfo),
+			Nodes:           make(map[string]*api.NodeInfo),
+			Queues:          make(map[api.QueueID]*api.QueueInfo),
+			PriorityClasses: make(map[string]*v1beta1.PriorityClass),
+			Recorder:        record.NewFakeRecorder(100),
+		},
+		defaultQueue:  defaultQueue,
+		unschedulable: make(map[string]string),
+	}
+}
+
+// Add adds the object into the cache; Node, Pod, PodGroup, Queue and
+// PriorityClass are supported. The status of tasks recorded in snapshots
+// is restored.
+func (c *Cache) Add(obj runtime.Object) error {
+	switch o := obj.(type) {
+	case *v1.Node:
+		c.cache.AddNode(o)
+	case *v1.Pod:
+		return c.cache.AddSnapshotPod(o)
+	case *kbv1.PodGroup:
+		if len(o.Spec.Queue) == 0 {
+			o = o.DeepCopy()
+			o.Spec.Queue = c.defaultQueue
+		}
+		c.cache.AddPodGroup(o)
+	case *kbv1.Queue:
+		c.cache.AddQueue(o)
+	case *v1beta1.PriorityClass:
+		c.cache.AddPriorityClass(o)
+	default:
+		return fmt.Errorf("unsupported object type %T", obj)
+	}
+
+	return nil
+}
+
+// Run does nothing, as there is no informer in the in-memory cache.
+func (c *Cache) Run(stopCh <-chan struct{}) {}
+
+// WaitForCacheSync returns true, as objects are added synchronously.
+func (c *Cache) WaitForCacheSync(stopCh <-chan struct{}) bool {
+	return true
+}
+
+// Snapshot deep copy overall cache information into snapshot
+func (c *Cache) Snapshot() *api.ClusterInfo {
+	return c.cache.Snapshot()
+}
+
+// Bind assumes the pod is started on the host right away.
+func (c *Cache) Bind(task *api.TaskInfo, hostname string) error {
+	pod := task.Pod.DeepCopy()
+	pod.Spec.NodeName = hostname
+	pod.Status.Phase = v1.PodRunning
+
+	c.cache.UpdatePod(task.Pod, pod)
+
+	c.Lock()
+	defer c.Unlock()
+
+	key := taskKey(task)
+	c.bindings = append(c.bindings, Binding{Task: key, Node: hostname})
+	delete(c.unschedulable, key)
+
+	return nil
+}
+
+// Evict assumes the pod is deleted right away.
+func (c *Cache) Evict(task *api.TaskInfo, reason string) error {
+	c.cache.DeletePod(task.Pod)
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.evictions = append(c.evictions, Eviction{Task: taskKey(task), Node: task.NodeName, Reason: reason})
+
+	return nil
+}
+
+// RecordJobStatusEvent records why the pending tasks of job are unschedulable.
+func (c *Cache) RecordJobStatusEvent(job *api.JobInfo) {
+	c.Lock()
+	defer c.Unlock()
+
+	baseErrorMessage := job.JobFitErrors
+	if baseErrorMessage == "" {
+		baseErrorMessage = api.AllNodeUnavailableMsg
+	}
+
+	for _, status := range []api.TaskStatus{api.Allocated, api.Pending} {
+		for _, task := range job.TaskStatusIndex[status] {
+			msg := baseErrorMessage
+			if fitError := job.NodesFitErrors[task.UID]; fitError != nil {
+				msg = fitError.Error()
+			}
+			c.unschedulable[taskKey(task)] = msg
+		}
+	}
+}
+
+// UpdateJobStatus updates the PodGroup of job in cache.
+func (c *Cache) UpdateJobStatus(job *api.JobInfo, updatePG bool) (*api.JobInfo, error) {
+	if updatePG && job.PodGroup != nil {
+		c.cache.UpdatePodGroup(job.PodGroup, job.PodGroup.DeepCopy())
+	}
+
+	c.RecordJobStatusEvent(job)
+
+	return job, nil
+}
+
+// AllocateVolumes does nothing, as volumes are not simulated.
+func (c *Cache) AllocateVolumes(task *api.TaskInfo, hostname string) error {
+	return nil
+}
+
+// BindVolumes does nothing, as volumes are not simulated.
+func (c *Cache) BindVolumes(task *api.TaskInfo) error {
+	return nil
+}
+
+// flush returns the bindings and evictions recorded since last flush, and
+// the unschedulable reasons of tasks which are still pending.
+func (c *Cache) flush() ([]Binding, []Eviction, map[string]string) {
+	c.Lock()
+	defer c.Unlock()
+
+	bindings, evictions := c.bindings, c.evictions
+	c.bindings, c.evictions = nil, nil
+
+	unschedulable := make(map[string]string, len(c.unschedulable))
+	for task, reason := range c.unschedulable {
+		unschedulable[task] = reason
+	}
+	c.unschedulable = make(map[string]string)
+
+	return bindings, evictions, unschedulable
+}
+
+func taskKey(task *api.TaskInfo) string {
+	return fmt.Sprintf("%s/%s", task.Namespace, task.Name)
+}
+
+// Make sure the in-memory cache implements cache.Cache.
+var _ schedcache.Cache = &Cache{}
@@ -0,0 +1,171 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+
+	kbv1 "volcano.sh/volcano/pkg/apis/scheduling/v1alpha1"
+	vkscheme "volcano.sh/volcano/pkg/client/clientset/versioned/scheme"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(kubescheme.AddToScheme(scheme))
+	utilruntime.Must(vkscheme.AddToScheme(scheme))
+}
+
+// LoadObjects loads objects from the YAML files; if a path is a directory,
+// all the .yaml, .yml and .json files in it are loaded. A file may contain
+// multiple objects separated by "---", and List objects are expanded.
+func LoadObjects(paths []string) ([]runtime.Object, error) {
+	var objs []runtime.Object
+
+	for _, path := range paths {
+		files, err := listFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			fileObjs, err := DecodeObjects(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %v", file, err)
+			}
+			objs = append(objs, fileObjs...)
+		}
+	}
+
+	return objs, nil
+}
+
+// DecodeObjects decodes the objects from YAML or JSON documents.
+func DecodeObjects(data []byte) ([]runtime.Object, error) {
+	var objs []runtime.Object
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if list, ok := obj.(*v1.List); ok {
+			for _, item := range list.Items {
+				itemObj, _, err := decoder.Decode(item.Raw, nil, nil)
+				if err != nil {
+					return nil, err
+				}
+				objs = append(objs, itemObj)
+			}
+			continue
+		}
+
+		objs = append(objs, obj)
+	}
+
+	for _, obj := range objs {
+		setDefaults(obj)
+	}
+
+	return objs, nil
+}
+
+// setDefaults fills the fields usually set by api server, which are required
+// by the scheduler cache.
+func setDefaults(obj runtime.Object) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+
+	switch obj.(type) {
+	case *v1.Pod, *kbv1.PodGroup:
+		if len(accessor.GetNamespace()) == 0 {
+			accessor.SetNamespace(metav1.NamespaceDefault)
+		}
+	}
+
+	if len(accessor.GetUID()) == 0 {
+		uid := accessor.GetName()
+		if ns := accessor.GetNamespace(); len(ns) != 0 {
+			uid = ns + "/" + uid
+		}
+		accessor.SetUID(types.UID(uid))
+	}
+}
+
+func listFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(file) {
+		case ".yaml", ".yml", ".json":
+			if !info.IsDir() {
+				files = append(files, file)
+			}
+		}
+		return nil
+	})
+
+	return files, err
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/golang/glog"
+
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// CycleResult is the result of one scheduling cycle.
+type CycleResult struct {
+	Cycle     int
+	Bindings  []Binding
+	Evictions []Eviction
+
+	// Unschedulable is the reason of tasks which are still pending after
+	// the cycle, keyed by task.
+	Unschedulable map[string]string
+}
+
+// Simulator runs scheduling sessions against an in-memory cache, without
+// touching any cluster.
+type Simulator struct {
+	cache   *Cache
+	actions []framework.Action
+	tiers   []conf.Tier
+}
+
+// New returns a simulator running actions and tiers on the cache.
+func New(cache *Cache, actions []framework.Action, tiers []conf.Tier) *Simulator {
+	return &Simulator{
+		cache:   cache,
+		actions: actions,
+		tiers:   tiers,
+	}
+}
+
+// Run runs the given number of scheduling cycles; the bindings and evictions
+// of a cycle are applied to the cache before next cycle starts.
+func (s *Simulator) Run(cycles int) []CycleResult {
+	var results []CycleResult
+
+	for i := 1; i <= cycles; i++ {
+		glog.V(3).Infof("Start simulating cycle %d ...", i)
+
+		ssn := framework.OpenSession(s.cache, s.tiers)
+		for _, action := range s.actions {
+			action.Execute(ssn)
+		}
+		framework.CloseSession(ssn)
+
+		bindings, evictions, unschedulable := s.cache.flush()
+		results = append(results, CycleResult{
+			Cycle:         i,
+			Bindings:      bindings,
+			Evictions:     evictions,
+			Unschedulable: unschedulable,
+		})
+	}
+
+	return results
+}
+
+// PrintResults prints the bindings, evictions and unschedulable reasons of
+// each cycle.
+func PrintResults(w io.Writer, results []CycleResult) {
+	for _, result := range results {
+		fmt.Fprintf(w, "Cycle %d:\n", result.Cycle)
+
+		fmt.Fprintf(w, "  Bindings (%d):\n", len(result.Bindings))
+		for _, b := range result.Bindings {
+			fmt.Fprintf(w, "    %s -> %s\n", b.Task, b.Node)
+		}
+
+		fmt.Fprintf(w, "  Evictions (%d):\n", len(result.Evictions))
+		for _, e := range result.Evictions {
+			fmt.Fprintf(w, "    %s on %s: %s\n", e.Task, e.Node, e.Reason)
+		}
+
+		tasks := make([]string, 0, len(result.Unschedulable))
+		for task := range result.Unschedulable {
+			tasks = append(tasks, task)
+		}
+		sort.Strings(tasks)
+
+		fmt.Fprintf(w, "  Unschedulable (%d):\n", len(tasks))
+		for _, task := range tasks {
+			fmt.Fprintf(w, "    %s: %s\n", task, result.Unschedulable[task])
+		}
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2019 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/drf"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
+)
+
+const cluster = `
+apiVersion: v1
+kind: Node
+metadata:
+  name: n1
+status:
+  allocatable:
+    cpu: "4"
+    memory: 8Gi
+    pods: "110"
+---
+apiVersion: scheduling.incubator.k8s.io/v1alpha1
+kind: Queue
+metadata:
+  name: default
+spec:
+  weight: 1
+---
+apiVersion: scheduling.incubator.k8s.io/v1alpha1
+kind: PodGroup
+metadata:
+  name: pg1
+  namespace: c1
+spec:
+  minMember: 2
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: p1
+    namespace: c1
+    annotations:
+      scheduling.k8s.io/group-name: pg1
+  spec:
+    containers:
+    - name: c
+      resources:
+        requests:
+          cpu: "2"
+          memory: 1Gi
+  status:
+    phase: Pending
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: p2
+    namespace: c1
+    annotations:
+      scheduling.k8s.io/group-name: pg1
+  spec:
+    containers:
+    - name: c
+      resources:
+        requests:
+          cpu: "2"
+          memory: 1Gi
+  status:
+    phase: Pending
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: p3
+    namespace: c1
+    annotations:
+      scheduling.k8s.io/group-name: pg1
+  spec:
+    containers:
+    - name: c
+      resources:
+        requests:
+          cpu: "2"
+          memory: 1Gi
+  status:
+    phase: Pending
+`
+
+func TestSimulator(t *testing.T) {
+	framework.RegisterPluginBuilder("gang", gang.New)
+	framework.RegisterPluginBuilder("drf", drf.New)
+	framework.RegisterPluginBuilder("proportion", proportion.New)
+	defer framework.CleanupPluginBuilders()
+
+	objs, err := DecodeObjects([]byte(cluster))
+	if err != nil {
+		t.Fatalf("Failed to decode objects: %v", err)
+	}
+	if len(objs) != 6 {
+		t.Fatalf("Expected 6 objects, got %d", len(objs))
+	}
+
+	cache := NewCache("default")
+	for _, obj := range objs {
+		if err := cache.Add(obj); err != nil {
+			t.Fatalf("Failed to add object: %v", err)
+		}
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               "gang",
+					EnabledJobReady:    &trueValue,
+					EnabledJobValid:    &trueValue,
+					EnabledJobOrder:    &trueValue,
+					EnabledPreemptable: &trueValue,
+				},
+				{
+					Name:               "drf",
+					EnabledJobOrder:    &trueValue,
+					EnabledPreemptable: &trueValue,
+				},
+				{
+					Name:               "proportion",
+					EnabledQueueOrder:  &trueValue,
+					EnabledReclaimable: &trueValue,
+					EnabledOverused:    &trueValue,
+				},
+			},
+		},
+	}
+
+	results := New(cache, []framework.Action{allocate.New()}, tiers).Run(2)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 cycles, got %d", len(results))
+	}
+
+	expectedBindings := []Binding{{Task: "c1/p1", Node: "n1"}, {Task: "c1/p2", Node: "n1"}}
+	bindings := results[0].Bindings
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].Task < bindings[j].Task })
+	if !reflect.DeepEqual(bindings, expectedBindings) {
+		t.Errorf("Expected bindings %v in first cycle, got %v", expectedBindings, bindings)
+	}
+	if len(results[1].Bindings) != 0 {
+		t.Errorf("Expected no binding in second cycle, got %v", results[1].Bindings)
+	}
+
+	for _, result := range results {
+		if _, found := result.Unschedulable["c1/p3"]; !found || len(result.Unschedulable) != 1 {
+			t.Errorf("Expected c1/p3 to be unschedulable in cycle %d, got %v", result.Cycle, result.Unschedulable)
+		}
+	}
+
+	snapshot := cache.Snapshot()
+	if used := snapshot.Nodes["n1"].Used.MilliCPU; used != 4000 {
+		t.Errorf("Expected 4000m cpu used on n1, got %v", used)
+	}
+}
@@ -72,6 +72,20 @@ func loadSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, error)
 	return actions, schedulerConf.Tiers, nil
 }
 
+// LoadSchedulerConf loads actions and tiers from the configuration file, or
+// from the default configuration if confPath is empty.
+func LoadSchedulerConf(confPath string) ([]framework.Action, []conf.Tier, error) {
+	schedConf := defaultSchedulerConf
+	if len(confPath) != 0 {
+		var err error
+		if schedConf, err = readSchedulerConf(confPath); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return loadSchedulerConf(schedConf)
+}
+
 func readSchedulerConf(confPath string) (string, error) {
 	dat, err := ioutil.ReadFile(confPath)
 	if err != nil {
@@ -22,25 +22,102 @@ import (
 	"math/rand"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/golang/glog"
 	"k8s.io/client-go/util/workqueue"
 
 	schedulerapi "k8s.io/kubernetes/pkg/scheduler/api"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/api"
 )
 
-// PredicateNodes returns nodes that fit task
+const (
+	baselineParallelism = 16
+	// minFeasibleNodesToFind is the minimal number of feasible nodes to find
+	// for a task, unless there are fewer nodes in cluster.
+	minFeasibleNodesToFind = 100
+)
+
+// lastProcessedNodeIndex is where to start predicating nodes next time, so
+// that the nodes are sampled evenly across tasks.
+var lastProcessedNodeIndex struct {
+	sync.Mutex
+	index int
+}
+
+// parallelism returns the number of workers evaluating the plugin functions
+// concurrently, so PredicateFn, NodeOrderFn, NodeMapFn must be safe for
+// concurrent use.
+func parallelism() int {
+	if options.ServerOpts != nil && options.ServerOpts.Parallelism > 0 {
+		return options.ServerOpts.Parallelism
+	}
+	return baselineParallelism
+}
+
+// numFeasibleNodesToFind returns the number of feasible nodes which is enough
+// to find for a task in a cluster of numAllNodes nodes.
+func numFeasibleNodesToFind(numAllNodes int) int {
+	percentage := 100
+	if options.ServerOpts != nil && options.ServerOpts.PercentageOfNodesToFind > 0 {
+		percentage = options.ServerOpts.PercentageOfNodesToFind
+	}
+
+	if numAllNodes <= minFeasibleNodesToFind || percentage >= 100 {
+		return numAllNodes
+	}
+
+	numNodes := numAllNodes * percentage / 100
+	if numNodes < minFeasibleNodesToFind {
+		return minFeasibleNodesToFind
+	}
+	return numNodes
+}
+
+// PredicateNodes returns all the nodes that fit task
 func PredicateNodes(task *api.TaskInfo, nodes []*api.NodeInfo, fn api.PredicateFn) ([]*api.NodeInfo, *api.FitErrors) {
+	feasibleNodes, fe, _ := predicateNodes(task, nodes, fn, len(nodes), 0)
+	return feasibleNodes, fe
+}
+
+// PredicateSampledNodes returns the nodes that fit task, it stops once
+// enough feasible nodes are found according to percentage-of-nodes-to-find;
+// the next call starts from the node after the last one checked.
+func PredicateSampledNodes(task *api.TaskInfo, nodes []*api.NodeInfo, fn api.PredicateFn) ([]*api.NodeInfo, *api.FitErrors) {
+	numNodesToFind := numFeasibleNodesToFind(len(nodes))
+	if numNodesToFind >= len(nodes) {
+		return PredicateNodes(task, nodes, fn)
+	}
+
+	lastProcessedNodeIndex.Lock()
+	defer lastProcessedNodeIndex.Unlock()
+
+	start := lastProcessedNodeIndex.index % len(nodes)
+	feasibleNodes, fe, processed := predicateNodes(task, nodes, fn, numNodesToFind, start)
+	lastProcessedNodeIndex.index = start + processed
+
+	return feasibleNodes, fe
+}
+
+// predicateNodes checks the nodes from index start in a round robin way,
+// until numNodesToFind feasible nodes are found. It also returns the number
+// of nodes checked.
+func predicateNodes(task *api.TaskInfo, nodes []*api.NodeInfo, fn api.PredicateFn, numNodesToFind, start int) ([]*api.NodeInfo, *api.FitErrors, int) {
 	var predicateNodes []*api.NodeInfo
+	var processed int32
 
 	var workerLock sync.Mutex
 
 	var errorLock sync.Mutex
 	fe := api.NewFitErrors()
 
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
 	checkNode := func(index int) {
-		node := nodes[index]
+		node := nodes[(start+index)%len(nodes)]
+		atomic.AddInt32(&processed, 1)
 		glog.V(3).Infof("Considering Task <%v/%v> on node <%v>: <%v> vs. <%v>",
 			task.Namespace, task.Name, node.Name, task.Resreq, node.Idle)
 
@@ -55,12 +132,18 @@ func PredicateNodes(task *api.TaskInfo, nodes []*api.NodeInfo, fn api.PredicateF
 		}
 
 		workerLock.Lock()
+		defer workerLock.Unlock()
+		if len(predicateNodes) >= numNodesToFind {
+			return
+		}
 		predicateNodes = append(predicateNodes, node)
-		workerLock.Unlock()
+		if len(predicateNodes) >= numNodesToFind {
+			cancel()
+		}
 	}
 
-	workqueue.ParallelizeUntil(context.TODO(), 16, len(nodes), checkNode)
-	return predicateNodes, fe
+	workqueue.ParallelizeUntil(ctx, parallelism(), len(nodes), checkNode)
+	return predicateNodes, fe, int(processed)
 }
 
 // PrioritizeNodes returns a map whose key is node's score and value are corresponding nodes
@@ -91,7 +174,7 @@ func PrioritizeNodes(task *api.TaskInfo, nodes []*api.NodeInfo, batchFn api.Batc
 		nodeOrderScoreMap[node.Name] = orderScore
 		workerLock.Unlock()
 	}
-	workqueue.ParallelizeUntil(context.TODO(), 16, len(nodes), scoreNode)
+	workqueue.ParallelizeUntil(context.TODO(), parallelism(), len(nodes), scoreNode)
 	reduceScores, err := reduceFn(task, pluginNodeScoreMap)
 	if err != nil {
 		glog.Errorf("Error in Calculating Priority for the node:%v", err)
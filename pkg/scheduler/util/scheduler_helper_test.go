@@ -17,9 +17,11 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
+	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/api"
 )
 
@@ -61,3 +63,63 @@ func TestSelectBestNode(t *testing.T) {
 		}
 	}
 }
+
+func TestPredicateSampledNodes(t *testing.T) {
+	defer func(opts *options.ServerOption) { options.ServerOpts = opts }(options.ServerOpts)
+
+	var nodes []*api.NodeInfo
+	for i := 0; i < 400; i++ {
+		nodes = append(nodes, &api.NodeInfo{Name: fmt.Sprintf("node%d", i)})
+	}
+	// Only the nodes with even index are feasible.
+	fn := func(task *api.TaskInfo, node *api.NodeInfo) error {
+		var index int
+		fmt.Sscanf(node.Name, "node%d", &index)
+		if index%2 != 0 {
+			return fmt.Errorf("node %s is odd", node.Name)
+		}
+		return nil
+	}
+
+	cases := []struct {
+		percentage int
+		expected   int
+	}{
+		{percentage: 100, expected: 200},
+		{percentage: 50, expected: 200},
+		{percentage: 30, expected: 120},
+		{percentage: 10, expected: minFeasibleNodesToFind},
+	}
+
+	for i, test := range cases {
+		// A single worker checks exactly the nodes needed, so that the
+		// rounds do not overlap.
+		options.ServerOpts = &options.ServerOption{
+			Parallelism:             1,
+			PercentageOfNodesToFind: test.percentage,
+		}
+
+		found := map[string]bool{}
+		// Sampling twice should find different nodes while there are
+		// enough feasible nodes.
+		for round := 0; round < 2; round++ {
+			feasibleNodes, _ := PredicateSampledNodes(&api.TaskInfo{}, nodes, fn)
+			if len(feasibleNodes) != test.expected {
+				t.Errorf("Failed test case #%d round %d, expected %d feasible nodes, got %d",
+					i, round, test.expected, len(feasibleNodes))
+			}
+			for _, node := range feasibleNodes {
+				found[node.Name] = true
+			}
+		}
+
+		expectedFound := 2 * test.expected
+		if expectedFound > 200 {
+			expectedFound = 200
+		}
+		if len(found) != expectedFound {
+			t.Errorf("Failed test case #%d, expected %d distinct feasible nodes, got %d",
+				i, expectedFound, len(found))
+		}
+	}
+}
@@ -44,92 +44,148 @@ tiers:
 		{
 			Plugins: []conf.PluginOption{
 				{
-					Name:                "priority",
-					EnabledJobOrder:     &trueValue,
-					EnabledJobReady:     &trueValue,
-					EnabledJobPipelined: &trueValue,
-					EnabledTaskOrder:    &trueValue,
-					EnabledPreemptable:  &trueValue,
-					EnabledReclaimable:  &trueValue,
-					EnabledQueueOrder:   &trueValue,
-					EnabledPredicate:    &trueValue,
-					EnabledNodeOrder:    &trueValue,
+					Name:                  "priority",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledBatchNodeOrder: &trueValue,
+					EnabledNodeMap:        &trueValue,
+					EnabledNodeReduce:     &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledJobValid:       &trueValue,
+					EnabledJobEnqueueable: &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledTargetJob:      &trueValue,
 				},
 				{
-					Name:                "gang",
-					EnabledJobOrder:     &trueValue,
-					EnabledJobReady:     &trueValue,
-					EnabledJobPipelined: &trueValue,
-					EnabledTaskOrder:    &trueValue,
-					EnabledPreemptable:  &trueValue,
-					EnabledReclaimable:  &trueValue,
-					EnabledQueueOrder:   &trueValue,
-					EnabledPredicate:    &trueValue,
-					EnabledNodeOrder:    &trueValue,
+					Name:                  "gang",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledBatchNodeOrder: &trueValue,
+					EnabledNodeMap:        &trueValue,
+					EnabledNodeReduce:     &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledJobValid:       &trueValue,
+					EnabledJobEnqueueable: &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledTargetJob:      &trueValue,
 				},
 				{
-					Name:                "conformance",
-					EnabledJobOrder:     &trueValue,
-					EnabledJobReady:     &trueValue,
-					EnabledJobPipelined: &trueValue,
-					EnabledTaskOrder:    &trueValue,
-					EnabledPreemptable:  &trueValue,
-					EnabledReclaimable:  &trueValue,
-					EnabledQueueOrder:   &trueValue,
-					EnabledPredicate:    &trueValue,
-					EnabledNodeOrder:    &trueValue,
+					Name:                  "conformance",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledBatchNodeOrder: &trueValue,
+					EnabledNodeMap:        &trueValue,
+					EnabledNodeReduce:     &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledJobValid:       &trueValue,
+					EnabledJobEnqueueable: &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledTargetJob:      &trueValue,
 				},
 			},
 		},
 		{
 			Plugins: []conf.PluginOption{
 				{
-					Name:                "drf",
-					EnabledJobOrder:     &trueValue,
-					EnabledJobReady:     &trueValue,
-					EnabledJobPipelined: &trueValue,
-					EnabledTaskOrder:    &trueValue,
-					EnabledPreemptable:  &trueValue,
-					EnabledReclaimable:  &trueValue,
-					EnabledQueueOrder:   &trueValue,
-					EnabledPredicate:    &trueValue,
-					EnabledNodeOrder:    &trueValue,
+					Name:                  "drf",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledBatchNodeOrder: &trueValue,
+					EnabledNodeMap:        &trueValue,
+					EnabledNodeReduce:     &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledJobValid:       &trueValue,
+					EnabledJobEnqueueable: &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledTargetJob:      &trueValue,
 				},
 				{
-					Name:                "predicates",
-					EnabledJobOrder:     &trueValue,
-					EnabledJobReady:     &trueValue,
-					EnabledJobPipelined: &trueValue,
-					EnabledTaskOrder:    &trueValue,
-					EnabledPreemptable:  &trueValue,
-					EnabledReclaimable:  &trueValue,
-					EnabledQueueOrder:   &trueValue,
-					EnabledPredicate:    &trueValue,
-					EnabledNodeOrder:    &trueValue,
+					Name:                  "predicates",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledBatchNodeOrder: &trueValue,
+					EnabledNodeMap:        &trueValue,
+					EnabledNodeReduce:     &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledJobValid:       &trueValue,
+					EnabledJobEnqueueable: &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledTargetJob:      &trueValue,
 				},
 				{
-					Name:                "proportion",
-					EnabledJobOrder:     &trueValue,
-					EnabledJobReady:     &trueValue,
-					EnabledJobPipelined: &trueValue,
-					EnabledTaskOrder:    &trueValue,
-					EnabledPreemptable:  &trueValue,
-					EnabledReclaimable:  &trueValue,
-					EnabledQueueOrder:   &trueValue,
-					EnabledPredicate:    &trueValue,
-					EnabledNodeOrder:    &trueValue,
+					Name:                  "proportion",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledBatchNodeOrder: &trueValue,
+					EnabledNodeMap:        &trueValue,
+					EnabledNodeReduce:     &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledJobValid:       &trueValue,
+					EnabledJobEnqueueable: &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledTargetJob:      &trueValue,
 				},
 				{
-					Name:                "nodeorder",
-					EnabledJobOrder:     &trueValue,
-					EnabledJobReady:     &trueValue,
-					EnabledJobPipelined: &trueValue,
-					EnabledTaskOrder:    &trueValue,
-					EnabledPreemptable:  &trueValue,
-					EnabledReclaimable:  &trueValue,
-					EnabledQueueOrder:   &trueValue,
-					EnabledPredicate:    &trueValue,
-					EnabledNodeOrder:    &trueValue,
+					Name:                  "nodeorder",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledBatchNodeOrder: &trueValue,
+					EnabledNodeMap:        &trueValue,
+					EnabledNodeReduce:     &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledJobValid:       &trueValue,
+					EnabledJobEnqueueable: &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledTargetJob:      &trueValue,
 				},
 			},
 		},
@@ -144,3 +200,32 @@ tiers:
 			expectedTiers, tiers)
 	}
 }
+
+func TestLoadSchedulerConfDisabledNodeOrder(t *testing.T) {
+	configuration := `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: nodeorder
+    enableNodeOrder: false
+    enableNodeReduce: true
+  - name: proportion
+    enableOverused: false
+`
+
+	_, tiers, err := loadSchedulerConf(configuration)
+	if err != nil {
+		t.Fatalf("Failed to load scheduler configuration: %v", err)
+	}
+
+	nodeorder, proportion := tiers[0].Plugins[0], tiers[0].Plugins[1]
+	if *nodeorder.EnabledBatchNodeOrder || *nodeorder.EnabledNodeMap {
+		t.Errorf("expected node scoring of nodeorder disabled with enableNodeOrder")
+	}
+	if !*nodeorder.EnabledNodeReduce {
+		t.Errorf("expected enableNodeReduce of nodeorder kept as set")
+	}
+	if *proportion.EnabledOverused || !*proportion.EnabledQueueOrder {
+		t.Errorf("expected only overused of proportion disabled")
+	}
+}